@@ -0,0 +1,187 @@
+package validator
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// binding is a custom validator matching the sub tag of a validation node together with its parsed validation context
+type binding struct {
+	customValidator *cv.CustomValidator
+	validationCtx   *cv.ValidationContext
+}
+
+// compileField parses the tags of a struct field, expands all aliases and macros
+// and binds all validation nodes to the custom validators matching their sub tags.
+// Returns a TagSyntaxError if the tags cannot be parsed, an alias is cyclic
+// or the arguments of a sub tag do not match the parameters of a custom validator.
+func (v *Validator) compileField(structType reflect.Type, structField reflect.StructField) (*Node, error) {
+	node, err := v.parseFieldTags(structField)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.compile(structType, node)
+}
+
+// compile expands all aliases and macros of the expression tree and binds its validation nodes
+func (v *Validator) compile(structType reflect.Type, node *Node) (*Node, error) {
+	node, err := v.expand(node, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = v.bind(structType, node)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// bind binds all validation nodes of the expression tree to the custom validators matching their sub tags.
+// The bindings of a node are sorted by the ids of their custom validators, so that their order is deterministic.
+func (v *Validator) bind(structType reflect.Type, node *Node) error {
+	customValidators := v.sortedCustomValidators()
+
+	for _, validation := range node.Validations() {
+		validation.bindings = nil
+
+		for _, customValidator := range customValidators {
+			if !customValidator.MatchesSubTag(validation.SubTag) {
+				continue
+			}
+
+			validationCtx, err := customValidator.NewValidationContext(validation.SubTag)
+			if err != nil {
+				return SyntaxErrorf("invalid arguments for validation %v: %v", validation.SubTag, err).
+					WithField("validator", customValidator.ID)
+			}
+
+			for _, arg := range validationCtx.Args {
+				ref, ok := arg.Value.(cv.FieldRef)
+				if !ok || structType == nil {
+					continue
+				}
+
+				if !hasFieldPath(structType, ref.Path) {
+					return SyntaxErrorf("validation %v references unknown field %v", validation.SubTag, ref.Path).
+						WithField("validator", customValidator.ID)
+				}
+			}
+
+			validation.bindings = append(validation.bindings, binding{
+				customValidator: customValidator,
+				validationCtx:   validationCtx,
+			})
+		}
+	}
+
+	return nil
+}
+
+// sortedCustomValidators returns the registered custom validators sorted by their ids
+func (v *Validator) sortedCustomValidators() []*cv.CustomValidator {
+	customValidators := make([]*cv.CustomValidator, 0, len(v.CustomValidators))
+	for _, customValidator := range v.CustomValidators {
+		customValidators = append(customValidators, customValidator)
+	}
+
+	sort.Slice(customValidators, func(i, j int) bool {
+		return customValidators[i].ID < customValidators[j].ID
+	})
+
+	return customValidators
+}
+
+// resolveValidationContext returns a copy of the validation context for the provided field in which all field references are resolved
+func resolveValidationContext(field *cv.Field, validationCtx *cv.ValidationContext) *cv.ValidationContext {
+	resolved := *validationCtx
+	resolved.FieldName = field.StructField.Name
+	resolved.Args = make([]cv.Arg, len(validationCtx.Args))
+
+	for i, arg := range validationCtx.Args {
+		if ref, ok := arg.Value.(cv.FieldRef); ok {
+			arg.Value = getFieldByPath(field.Struct, ref.Path)
+		}
+		resolved.Args[i] = arg
+	}
+
+	return &resolved
+}
+
+// hasFieldPath checks if a field path of the form Field.NestedField exists on a struct type
+func hasFieldPath(structType reflect.Type, path string) bool {
+	rType := structType
+	for _, name := range strings.Split(path, ".") {
+		rType = getUnderlyingType(rType)
+		if rType.Kind() != reflect.Struct {
+			return false
+		}
+
+		structField, ok := rType.FieldByName(name)
+		if !ok {
+			return false
+		}
+		rType = structField.Type
+	}
+
+	return true
+}
+
+// getFieldByPath returns the value of a field path of the form Field.NestedField on a struct value.
+// Returns an invalid reflect.Value if the path cannot be resolved e.g. because of a nil pointer.
+func getFieldByPath(structValue reflect.Value, path string) reflect.Value {
+	value := structValue
+	for _, name := range strings.Split(path, ".") {
+		for value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return reflect.Value{}
+			}
+			value = value.Elem()
+		}
+
+		if value.Kind() != reflect.Struct {
+			return reflect.Value{}
+		}
+
+		value = value.FieldByName(name)
+	}
+
+	return value
+}
+
+// CompileTag parses a validator tag of the native syntax, expands all aliases and macros
+// and binds all validation nodes to the custom validators matching their sub tags.
+// It is intended for tools working with tags outside of a struct e.g. code generators, therefore field references are not checked.
+func (v *Validator) CompileTag(tag string) (*Node, error) {
+	node, err := parseTag(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.compile(nil, node)
+}
+
+// CompileStructTag compiles the validations of all configured tag keys of a struct field tag like CompileTag
+func (v *Validator) CompileStructTag(tag reflect.StructTag) (*Node, error) {
+	node, err := v.parseFieldTags(reflect.StructField{Tag: tag})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.compile(nil, node)
+}
+
+// CustomValidators returns the custom validators matching the sub tag of a compiled validation node
+func (node *Node) CustomValidators() []*cv.CustomValidator {
+	customValidators := make([]*cv.CustomValidator, len(node.bindings))
+	for i, b := range node.bindings {
+		customValidators[i] = b.customValidator
+	}
+
+	return customValidators
+}
@@ -0,0 +1,157 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+type InvalidArgumentStruct struct {
+	Field string `validator:"len(abc)"`
+}
+
+type InvalidArityStruct struct {
+	Field string `validator:"email || len(1,2)"`
+}
+
+type EqualFieldStruct struct {
+	Password     string
+	Confirmation string `validator:"eq-field(Password)"`
+	Nested       *EqualFieldNestedStruct
+}
+
+type EqualFieldNestedStruct struct {
+	Value string
+	Copy  string `validator:"eq-field(Value)"`
+}
+
+type UnknownFieldStruct struct {
+	Confirmation string `validator:"eq-field(Unknown)"`
+}
+
+func eqFieldValidator() *cv.CustomValidator {
+	eqFieldRegex := regexp.MustCompile(`eq-field\(.*\)`)
+
+	return cv.NewCustomValidator("eq-field", eqFieldRegex, func(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
+		other := vCtx.Arg(0).Field()
+		if !other.IsValid() || other.Interface() != f.Value.Interface() {
+			return fmt.Errorf("field %v must be equal to %v", f.StructField.Name, vCtx.Arg(0).Raw)
+		}
+		return nil
+	}, cv.NewCustomValidatorConfig(), cv.FieldParam("field"))
+}
+
+func TestValidator_Validate_failsForInvalidArguments(t *testing.T) {
+	validator := NewValidator()
+
+	values := []interface{}{
+		InvalidArgumentStruct{Field: "abc"},
+		// the arguments are checked even though the first operand succeeds
+		InvalidArityStruct{Field: ValidEmail},
+	}
+
+	for _, val := range values {
+		err := validator.Validate(context.Background(), val)
+
+		assert.IsType(t, &TagSyntaxError{}, err)
+	}
+}
+
+func TestValidator_CompileTag_failsForArgumentsOfZeroArityValidators(t *testing.T) {
+	validator := NewValidator()
+
+	for _, tag := range []string{"required(5)", "non-nil(x)", "non-zero(0)", "email(1)"} {
+		t.Run(tag, func(t *testing.T) {
+			_, err := validator.CompileTag(tag)
+
+			assert.IsType(t, &TagSyntaxError{}, err)
+		})
+	}
+}
+
+func TestValidator_Validate_fieldReference(t *testing.T) {
+	validator := NewValidator()
+	validator.RegisterCustomValidator(eqFieldValidator())
+
+	err := validator.Validate(context.Background(), EqualFieldStruct{
+		Password:     "secret",
+		Confirmation: "secret",
+		Nested:       &EqualFieldNestedStruct{Value: "a", Copy: "a"},
+	})
+	assert.NoError(t, err)
+
+	err = validator.Validate(context.Background(), EqualFieldStruct{Password: "secret", Confirmation: "other"})
+	assert.EqualError(t, err, "field Confirmation must be equal to Password")
+
+	err = validator.Validate(context.Background(), EqualFieldStruct{Nested: &EqualFieldNestedStruct{Value: "a", Copy: "b"}})
+	assert.EqualError(t, err, "field Copy must be equal to Value")
+}
+
+func TestValidator_Validate_failsForUnknownFieldReference(t *testing.T) {
+	validator := NewValidator()
+	validator.RegisterCustomValidator(eqFieldValidator())
+
+	err := validator.Validate(context.Background(), UnknownFieldStruct{})
+
+	assert.IsType(t, &TagSyntaxError{}, err)
+}
+
+type LiteralNamesStruct struct {
+	Contact string `validator:"oneof('email','phone')"`
+	Pattern string `validator:"regex('^required$') || len(0)"`
+	Emails  string `validator:"emails"`
+}
+
+func TestValidator_Validate_bindsBySubTagName(t *testing.T) {
+	validator := NewValidator()
+
+	// neither validator names within literals nor sub tags starting with a validator name are bound
+	assert.NoError(t, validator.Validate(context.Background(), LiteralNamesStruct{Contact: "phone"}))
+
+	node, err := validator.CompileTag(`oneof('email','phone') && regex('^required$') && emails`)
+	if assert.NoError(t, err) {
+		validations := node.Validations()
+		assert.Len(t, validations[0].CustomValidators(), 1)
+		assert.Equal(t, "oneof", validations[0].CustomValidators()[0].ID)
+		assert.Len(t, validations[1].CustomValidators(), 1)
+		assert.Equal(t, "regex", validations[1].CustomValidators()[0].ID)
+		assert.Empty(t, validations[2].CustomValidators())
+	}
+
+	err = validator.Validate(context.Background(), LiteralNamesStruct{Contact: "fax"})
+	assert.EqualError(t, err, `oneof field Contact has value "fax", but should be one of ["email" "phone"]`)
+}
+
+func TestValidator_CompileTag_sortsBindingsByID(t *testing.T) {
+	validator := NewValidator()
+	for _, id := range []string{"dup-c", "dup-a", "dup-b"} {
+		id := id
+		validator.RegisterCustomValidator(cv.NewCustomValidator(id, regexp.MustCompile(`^dup$`),
+			func(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
+				return fmt.Errorf("%v failed", id)
+			}, cv.NewCustomValidatorConfig()))
+	}
+
+	for i := 0; i < 10; i++ {
+		node, err := validator.CompileTag("dup")
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		var ids []string
+		for _, customValidator := range node.CustomValidators() {
+			ids = append(ids, customValidator.ID)
+		}
+		assert.Equal(t, []string{"dup-a", "dup-b", "dup-c"}, ids)
+
+		err = validator.Validate(context.Background(), struct {
+			Value string `validator:"dup"`
+		}{})
+		assert.EqualError(t, err, "dup-a failed")
+	}
+}
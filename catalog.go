@@ -0,0 +1,67 @@
+package validator
+
+import (
+	"sync"
+)
+
+// englishCatalog contains the english messages of the default custom validators, operators and type conversions
+var englishCatalog = Catalog{
+	Messages: map[string]string{
+		"required": `{{.Label}} is required`,
+		"non-nil":  `{{.Label}} must be set`,
+		"non-zero": `{{if .Unit}}{{.Label}} must not be empty{{else if eq .Kind "bool"}}{{.Label}} must be true{{else}}{{.Label}} must not be zero{{end}}`,
+		"email":    `{{.Label}} must be a valid e-mail address`,
+		"len":      `{{.Label}} must have exactly {{index .Params 0}} {{.Unit}}`,
+		"min":      `{{if .Unit}}{{.Label}} must have at least {{index .Params 0}} {{.Unit}}{{else}}{{.Label}} must be at least {{index .Params 0}}{{end}}`,
+		"max":      `{{if .Unit}}{{.Label}} must have at most {{index .Params 0}} {{.Unit}}{{else}}{{.Label}} must be at most {{index .Params 0}}{{end}}`,
+		"oneof":    `{{.Label}} must be one of {{join .Params ", "}}`,
+		"regex":    `{{.Label}} must match the pattern {{index .Params 0}}`,
+		"type":     `{{.Label}} must be a valid {{index .Params 0}}`,
+		"or":       `{{.Label}} is invalid`,
+		"not":      `{{.Label}} is invalid`,
+	},
+}
+
+// germanCatalog contains the german messages of the default custom validators, operators and type conversions
+var germanCatalog = Catalog{
+	Messages: map[string]string{
+		"required": `{{.Label}} ist erforderlich`,
+		"non-nil":  `{{.Label}} muss gesetzt sein`,
+		"non-zero": `{{if .Unit}}{{.Label}} darf nicht leer sein{{else if eq .Kind "bool"}}{{.Label}} muss wahr sein{{else}}{{.Label}} darf nicht null sein{{end}}`,
+		"email":    `{{.Label}} muss eine gültige E-Mail-Adresse sein`,
+		"len":      `{{.Label}} muss genau {{index .Params 0}} {{.Unit}} haben`,
+		"min":      `{{if .Unit}}{{.Label}} muss mindestens {{index .Params 0}} {{.Unit}} haben{{else}}{{.Label}} muss mindestens {{index .Params 0}} sein{{end}}`,
+		"max":      `{{if .Unit}}{{.Label}} darf höchstens {{index .Params 0}} {{.Unit}} haben{{else}}{{.Label}} darf höchstens {{index .Params 0}} sein{{end}}`,
+		"oneof":    `{{.Label}} muss einer der Werte {{join .Params ", "}} sein`,
+		"regex":    `{{.Label}} muss dem Muster {{index .Params 0}} entsprechen`,
+		"type":     `{{.Label}} muss ein gültiger Wert vom Typ {{index .Params 0}} sein`,
+		"or":       `{{.Label}} ist ungültig`,
+		"not":      `{{.Label}} ist ungültig`,
+	},
+	Units: map[string]string{
+		"characters": "Zeichen",
+		"elements":   "Elemente",
+		"entries":    "Einträge",
+	},
+}
+
+var (
+	bundledCatalogsOnce     sync.Once
+	bundledCatalogsCompiled map[string]*compiledCatalog
+)
+
+// bundledCatalogs returns the compiled catalogs that are bundled with the module by their locales
+func bundledCatalogs() map[string]*compiledCatalog {
+	bundledCatalogsOnce.Do(func() {
+		bundledCatalogsCompiled = map[string]*compiledCatalog{}
+		for locale, catalog := range map[string]Catalog{"en": englishCatalog, "de": germanCatalog} {
+			compiled, err := compileCatalog(locale, catalog)
+			if err != nil {
+				panic(err)
+			}
+			bundledCatalogsCompiled[locale] = compiled
+		}
+	})
+
+	return bundledCatalogsCompiled
+}
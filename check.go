@@ -0,0 +1,156 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// CheckError contains all problems found in the tags of a type and its nested types by Check
+type CheckError struct {
+	Type   reflect.Type
+	Errors []error
+}
+
+// Error returns the error message string containing all problems
+// Implements error interface
+func (err *CheckError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "tags of type %v contain %v problem(s):", err.Type, len(err.Errors))
+	for _, e := range err.Errors {
+		fmt.Fprintf(&sb, "\n\t%v", e)
+	}
+
+	return sb.String()
+}
+
+// Check parses the tags of all fields of a struct type including nested, pointer and collection element types
+// and verifies that every sub tag matches a registered custom validator with valid arguments that supports the kind of the field.
+// Returns a CheckError containing all problems or nil if all tags are valid.
+// Unlike the validation, which only detects problems of evaluated tags, Check can be used to fail at start-up.
+func (v *Validator) Check(rType reflect.Type) error {
+	if rType == nil {
+		return fmt.Errorf("check of a nil type is not supported")
+	}
+
+	structType := rType
+	for structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("check of kind %v is not supported", structType.Kind())
+	}
+
+	errs := v.checkStruct(structType, "", map[reflect.Type]bool{})
+	if len(errs) > 0 {
+		return &CheckError{
+			Type:   rType,
+			Errors: errs,
+		}
+	}
+
+	return nil
+}
+
+// MustRegisterType checks the tags of the type of the provided value by Check and panics if any tag is invalid.
+// Usage:
+//
+//	validator := NewValidator()
+//	validator.MustRegisterType(User{})
+func (v *Validator) MustRegisterType(i interface{}) {
+	err := v.Check(reflect.TypeOf(i))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// checkStruct checks all fields of a struct type, which is only checked once to support recursive types
+func (v *Validator) checkStruct(structType reflect.Type, path string, checked map[reflect.Type]bool) []error {
+	if checked[structType] {
+		return nil
+	}
+	checked[structType] = true
+
+	var errs []error
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		fieldPath := path + structField.Name
+
+		node, err := v.compileField(structType, structField)
+		if err != nil {
+			errs = append(errs, withPath(err, fieldPath))
+		} else if node != nil {
+			errs = append(errs, v.checkNode(node, structField.Type, fieldPath)...)
+		}
+
+		errs = append(errs, v.checkNested(structField.Type, fieldPath, checked)...)
+	}
+
+	return errs
+}
+
+// checkNested checks nested struct types of a field type including pointer and collection element types
+func (v *Validator) checkNested(fieldType reflect.Type, path string, checked map[reflect.Type]bool) []error {
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Struct:
+		return v.checkStruct(fieldType, path+".", checked)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.checkNested(fieldType.Elem(), path+"[]", checked)
+	}
+
+	return nil
+}
+
+// checkNode checks that all validation nodes are bound to a custom validator supporting the kind of the field
+func (v *Validator) checkNode(node *Node, fieldType reflect.Type, path string) []error {
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	kind := fieldType.Kind()
+
+	switch node.Kind {
+	case ValidationNode:
+		if len(node.bindings) == 0 {
+			return []error{withPath(SyntaxErrorf("validation %v does not match any custom validator", node.SubTag), path)}
+		}
+
+		var errs []error
+		for _, b := range node.bindings {
+			// the kind of interfaces is only known at runtime
+			if kind != reflect.Interface && b.customValidator.Config != nil && !b.customValidator.Config.SupportsKind(kind) {
+				errs = append(errs, withPath(SyntaxErrorf("validation %v does not support fields of kind %v", node.SubTag, kind).
+					WithField("validator", b.customValidator.ID), path))
+			}
+		}
+		return errs
+	case DiveNode:
+		switch kind {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return v.checkNode(node.Children[0], fieldType.Elem(), path+"[]")
+		case reflect.Interface:
+			return nil
+		}
+		return []error{withPath(SyntaxErrorf("dive validation of kind %v is not supported", kind), path)}
+	}
+
+	var errs []error
+	for _, child := range node.Children {
+		errs = append(errs, v.checkNode(child, fieldType, path)...)
+	}
+
+	return errs
+}
+
+// withPath adds the path of a field to tag syntax errors
+func withPath(err error, path string) error {
+	if syntaxErr, ok := err.(*TagSyntaxError); ok {
+		return syntaxErr.WithField("field-path", path)
+	}
+
+	return fmt.Errorf("field %v: %w", path, err)
+}
@@ -0,0 +1,85 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type CheckStruct struct {
+	Name    string   `validator:"required && len(9)"`
+	Email   string   `validator:"email || len(0)"`
+	Tags    []string `validator:"dive(required)"`
+	Address *CheckAddress
+	Orders  []CheckOrder
+	Parent  *CheckStruct
+}
+
+type CheckAddress struct {
+	Street string `validator:"requierd"`
+	Zip    int    `validator:"len(5)"`
+}
+
+type CheckOrder struct {
+	ID    string `validator:"len(abc)"`
+	Items int    `validator:"dive(required)"`
+	Total string `validator:"min(1"`
+}
+
+type ValidCheckStruct struct {
+	Name    string           `validator:"required && len(9)"`
+	Tags    map[string][]int `validator:"dive(dive(min(1)))"`
+	Any     interface{}      `validator:"len(3)"`
+	Nested  *ValidCheckStruct
+	Ignored string
+}
+
+func TestValidator_Check(t *testing.T) {
+	validator := NewValidator()
+
+	err := validator.Check(reflect.TypeOf(&ValidCheckStruct{}))
+	assert.NoError(t, err)
+
+	err = validator.Check(reflect.TypeOf(CheckStruct{}))
+	if assert.IsType(t, &CheckError{}, err) {
+		checkErr := err.(*CheckError)
+		assert.Equal(t, reflect.TypeOf(CheckStruct{}), checkErr.Type)
+		assert.Equal(t, []string{
+			"validation requierd does not match any custom validator: map[field-path:Address.Street]",
+			"validation len(5) does not support fields of kind int: map[field-path:Address.Zip validator:len]",
+			`invalid arguments for validation len(abc): len: parameter length of type int cannot be parsed from "abc": strconv.Atoi: parsing "abc": invalid syntax: map[field-path:Orders[].ID validator:len]`,
+			"dive validation of kind int is not supported: map[field-path:Orders[].Items]",
+			"missing closing bracket: map[field-path:Orders[].Total position:5 tag:min(1]",
+		}, errorMessages(checkErr.Errors))
+		assert.Contains(t, err.Error(), "tags of type validator.CheckStruct contain 5 problem(s):\n\tvalidation requierd")
+	}
+}
+
+func TestValidator_Check_failsForInvalidTypes(t *testing.T) {
+	validator := NewValidator()
+
+	assert.EqualError(t, validator.Check(nil), "check of a nil type is not supported")
+	assert.EqualError(t, validator.Check(reflect.TypeOf("")), "check of kind string is not supported")
+}
+
+func TestValidator_MustRegisterType(t *testing.T) {
+	validator := NewValidator()
+
+	assert.NotPanics(t, func() {
+		validator.MustRegisterType(ValidCheckStruct{})
+	})
+
+	assert.Panics(t, func() {
+		validator.MustRegisterType(&CheckOrder{})
+	})
+}
+
+func errorMessages(errs []error) []string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+
+	return messages
+}
@@ -0,0 +1,886 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gogo-gadget/validator"
+	"github.com/gogo-gadget/validator/internal/gotypes"
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+const (
+	validatorPath = "github.com/gogo-gadget/validator"
+	dvPath        = "github.com/gogo-gadget/validator/dv"
+	cvPath        = "github.com/gogo-gadget/validator/pkg/cv"
+
+	// nestedMethod is the generated method validating a struct whose fields are prefixed by the path of its parent fields
+	nestedMethod = "validatorGenValidate"
+)
+
+// constructors maps the ids of the supported custom validators to their constructors in the dv package
+var constructors = map[string]string{
+	"non-nil":  "NonNil",
+	"non-zero": "NonZero",
+	"required": "Required",
+	"email":    "Email",
+	"len":      "Len",
+	"min":      "Min",
+	"max":      "Max",
+	"oneof":    "OneOf",
+	"regex":    "Regex",
+}
+
+// value is a Go expression that is validated by the generated code
+type value struct {
+	// expr is the Go expression of the value e.g. s.Name
+	expr string
+	typ  types.Type
+	// name is a Go expression of the field name used in errors e.g. "Name" or the name of a slice element
+	name string
+	// path is a Go expression of the full field name e.g. prefix + "Name"
+	path string
+	// fieldName is the name of the struct field the value belongs to
+	fieldName string
+	// static reports whether name is constant, otherwise the validation contexts have to be copied
+	static bool
+}
+
+type generator struct {
+	pkg       *types.Package
+	validator *validator.Validator
+
+	imports     map[string]string
+	contexts    bytes.Buffer
+	contextVars map[string]string
+	checks      bytes.Buffer
+	body        bytes.Buffer
+
+	queue   []*types.Named
+	queued  map[*types.Named]bool
+	counter int
+}
+
+// generate creates the source of the Validate methods of the provided struct types of the package in dir.
+// The output file is excluded from the package since it is going to be replaced.
+func generate(dir string, typeNames []string, output string) ([]byte, error) {
+	pkg, err := loadPackage(dir, output)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		pkg:         pkg,
+		validator:   validator.NewValidator(),
+		imports:     map[string]string{},
+		contextVars: map[string]string{},
+		queued:      map[*types.Named]bool{},
+	}
+
+	if len(typeNames) == 0 {
+		typeNames = g.taggedTypes()
+	}
+
+	for _, typeName := range typeNames {
+		named, err := g.lookupStruct(typeName)
+		if err != nil {
+			return nil, err
+		}
+
+		if hasMethod(named, "Validate") {
+			return nil, fmt.Errorf("type %v already has a Validate method", typeName)
+		}
+		g.enqueue(named)
+	}
+
+	if len(g.queue) == 0 {
+		return nil, fmt.Errorf("no struct types with validator tags found in package %v", pkg.Name())
+	}
+
+	// nested struct types are appended to the queue while it is processed
+	for i := 0; i < len(g.queue); i++ {
+		err = g.generateType(g.queue[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return g.source()
+}
+
+// loadPackage parses and type checks the package in dir.
+// Type errors are ignored since the package may use methods that have not been generated yet.
+func loadPackage(dir string, output string) (*types.Package, error) {
+	buildPkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range buildPkg.GoFiles {
+		if name == filepath.Base(output) {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error:    func(err error) {},
+	}
+
+	pkg, _ := conf.Check(buildPkg.ImportPath, fset, files, nil)
+	return pkg, nil
+}
+
+// taggedTypes returns the names of all struct types of the package containing at least one validator tag
+func (g *generator) taggedTypes() []string {
+	var typeNames []string
+	for _, name := range g.pkg.Scope().Names() {
+		named, err := g.lookupStruct(name)
+		if err != nil {
+			continue
+		}
+
+		st := named.Underlying().(*types.Struct)
+		for i := 0; i < st.NumFields(); i++ {
+			if validatorTag(st, i) != "" {
+				typeNames = append(typeNames, name)
+				break
+			}
+		}
+	}
+
+	return typeNames
+}
+
+func (g *generator) lookupStruct(typeName string) (*types.Named, error) {
+	obj, ok := g.pkg.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type %v not found in package %v", typeName, g.pkg.Name())
+	}
+
+	named, ok := obj.Type().(*types.Named)
+	if !ok || named.TypeParams().Len() > 0 {
+		return nil, fmt.Errorf("type %v is not supported, only non generic named struct types are supported", typeName)
+	}
+
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil, fmt.Errorf("type %v is not a struct type", typeName)
+	}
+
+	return named, nil
+}
+
+func (g *generator) enqueue(named *types.Named) {
+	if g.queued[named] {
+		return
+	}
+
+	g.queued[named] = true
+	g.queue = append(g.queue, named)
+}
+
+func (g *generator) generateType(named *types.Named) error {
+	name := named.Obj().Name()
+	st := named.Underlying().(*types.Struct)
+
+	if !hasMethod(named, "Validate") {
+		fmt.Fprintf(&g.body, "// Validate validates %v by its validator tags without reflection.\n", name)
+		fmt.Fprintf(&g.body, "func (s *%v) Validate(ctx context.Context) error {\nreturn s.%v(ctx, \"\")\n}\n\n", name, nestedMethod)
+	}
+
+	fmt.Fprintf(&g.body, "func (s *%v) %v(ctx context.Context, prefix string) error {\n", name, nestedMethod)
+	fmt.Fprintf(&g.body, "if s == nil {\n")
+	regex, path, err := g.nilValidation(st, "", map[*types.Struct]bool{st: true})
+	if err != nil {
+		return fmt.Errorf("type %v: %w", name, err)
+	}
+
+	if path != "" {
+		fmt.Fprintf(&g.body, "return %v\n", g.fieldError("validation failed since validator for regex: "+regex+" failed on nil value for Field: ", "prefix + "+strconv.Quote(path), ""))
+	} else {
+		fmt.Fprintf(&g.body, "return nil\n")
+	}
+	fmt.Fprintf(&g.body, "}\n\n")
+
+	fmt.Fprintf(&g.checks, "validator.MustCheckGeneratedTags(%v{}, map[string]string{\n", name)
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		tag := validatorTag(st, i)
+
+		if field.Name() == "_" {
+			if tag != "" {
+				return fmt.Errorf("type %v: blank fields cannot be validated", name)
+			}
+			continue
+		}
+		fmt.Fprintf(&g.checks, "%q: %q,\n", field.Name(), tag)
+
+		if _, ok := reflect.StructTag(st.Tag(i)).Lookup(validator.MessageTagKey); ok {
+			return fmt.Errorf("type %v: field %v: %v tags are not supported", name, field.Name(), validator.MessageTagKey)
+		}
+
+		err = g.generateField(field, tag)
+		if err != nil {
+			return fmt.Errorf("type %v: field %v: %w", name, field.Name(), err)
+		}
+	}
+	fmt.Fprintf(&g.checks, "})\n")
+
+	fmt.Fprintf(&g.body, "return nil\n}\n\n")
+	return nil
+}
+
+func (g *generator) generateField(field *types.Var, tag string) error {
+	fieldName := field.Name()
+	val := value{
+		expr:      "s." + fieldName,
+		typ:       field.Type(),
+		name:      strconv.Quote(fieldName),
+		path:      "prefix + " + strconv.Quote(fieldName),
+		fieldName: fieldName,
+		static:    true,
+	}
+
+	node, err := g.validator.CompileTag(tag)
+	if err != nil {
+		return err
+	}
+
+	if node != nil {
+		fmt.Fprintf(&g.body, "// %v `validator:%q`\n", fieldName, tag)
+		errVar, err := g.generateNode(&g.body, node, val)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&g.body, "if %v != nil {\nreturn %v\n}\n\n", errVar, errVar)
+	}
+
+	return g.generateNested(val)
+}
+
+// generateNested validates nested structs of the package by their generated methods
+func (g *generator) generateNested(val value) error {
+	typ := val.typ
+	pointers := 0
+	for {
+		ptr, ok := typ.Underlying().(*types.Pointer)
+		if !ok {
+			break
+		}
+		typ = ptr.Elem()
+		pointers++
+	}
+
+	nestedPrefix := "prefix + " + strconv.Quote(val.fieldName+".")
+
+	switch typ.Underlying().(type) {
+	case *types.Struct:
+		named, ok := typ.(*types.Named)
+		if !ok || named.Obj().Pkg() != g.pkg || named.TypeParams().Len() > 0 {
+			if hasValidatorTags(typ, map[types.Type]bool{}) {
+				return fmt.Errorf("type %v contains validator tags but is not a struct type declared in package %v", typ, g.pkg.Name())
+			}
+			return nil
+		}
+
+		if pointers > 1 {
+			return fmt.Errorf("multiple pointer indirections are not supported")
+		}
+
+		if !hasMethod(named, nestedMethod) {
+			g.enqueue(named)
+		}
+
+		fmt.Fprintf(&g.body, "if err := %v.%v(ctx, %v); err != nil {\nreturn err\n}\n\n", val.expr, nestedMethod, nestedPrefix)
+	case *types.Interface:
+		if pointers > 0 {
+			return nil
+		}
+
+		// only pointers to structs of the package with generated methods can be validated
+		fmt.Fprintf(&g.body, "if nested, ok := %v.(interface {\n%v(context.Context, string) error\n}); ok {\n", val.expr, nestedMethod)
+		fmt.Fprintf(&g.body, "if err := nested.%v(ctx, %v); err != nil {\nreturn err\n}\n}\n\n", nestedMethod, nestedPrefix)
+	}
+
+	return nil
+}
+
+// generateNode writes the statements evaluating the node and returns the name of the variable containing its error
+func (g *generator) generateNode(w *bytes.Buffer, node *validator.Node, val value) (string, error) {
+	if node.Message != "" {
+		return "", fmt.Errorf("message %q of %v is not supported", node.Message, node.SubTag)
+	}
+
+	switch node.Kind {
+	case validator.ValidationNode:
+		return g.generateValidation(w, node, val)
+	case validator.AndNode, validator.OrNode:
+		return g.generateAndOr(w, node, val)
+	case validator.NotNode:
+		return g.generateNot(w, node, val)
+	case validator.IfNode:
+		return g.generateIf(w, node, val)
+	case validator.DiveNode:
+		return g.generateDive(w, node, val)
+	}
+
+	return "", fmt.Errorf("%v nodes are not supported", node.Kind)
+}
+
+func (g *generator) generateValidation(w *bytes.Buffer, node *validator.Node, val value) (string, error) {
+	errVar := g.newVar("err")
+	fmt.Fprintf(w, "var %v error\n", errVar)
+
+	customValidators := node.CustomValidators()
+	sort.Slice(customValidators, func(i, j int) bool {
+		return customValidators[i].ID < customValidators[j].ID
+	})
+
+	for i, customValidator := range customValidators {
+		stmts, err := g.validationCall(customValidator, node.SubTag, val, errVar)
+		if err != nil {
+			return "", err
+		}
+
+		if i > 0 {
+			stmts = fmt.Sprintf("if %v == nil {\n%v}\n", errVar, stmts)
+		}
+		w.WriteString(stmts)
+	}
+
+	return errVar, nil
+}
+
+// validationCall returns the statements assigning the error of a custom validator to errVar
+func (g *generator) validationCall(customValidator *cv.CustomValidator, subTag string, val value, errVar string) (string, error) {
+	constructor, ok := constructors[customValidator.ID]
+	if !ok {
+		return "", fmt.Errorf("custom validator %v is not supported", customValidator.ID)
+	}
+
+	var sb strings.Builder
+	vCtx := g.validationContext(constructor, subTag, val.fieldName)
+	if !val.static {
+		copyVar := g.newVar("vCtx")
+		fmt.Fprintf(&sb, "%v := *%v\n%v.FieldName = %v\n", copyVar, vCtx, copyVar, val.name)
+		vCtx = "&" + copyVar
+	}
+
+	typ := val.typ
+	x := val.expr
+	isNil := "false"
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		typ = ptr.Elem()
+		x = "*" + val.expr
+		isNil = val.expr + " == nil"
+	}
+
+	switch typ.Underlying().(type) {
+	case *types.Pointer, *types.Interface:
+		return "", fmt.Errorf("custom validator %v is not supported on fields of type %v", customValidator.ID, val.typ)
+	}
+
+	unsupported := fmt.Errorf("custom validator %v does not support fields of type %v", customValidator.ID, val.typ)
+
+	var call, nilErr string
+	switch customValidator.ID {
+	case "non-nil":
+		call = fmt.Sprintf("dv.ValidateNonNilValue(ctx, %v, %v)", isNil, vCtx)
+	case "non-zero", "required":
+		isZero := "false"
+		if isNil == "false" {
+			isZero, ok = g.zeroCheck(x, typ)
+			if !ok {
+				return "", unsupported
+			}
+		}
+		call = fmt.Sprintf("dv.Validate%vValue(ctx, %v, %v, %v)", constructor, isNil, isZero, vCtx)
+	case "email", "regex":
+		str, ok := stringOf(x, typ)
+		if !ok {
+			return "", unsupported
+		}
+		call = fmt.Sprintf("dv.Validate%vString(ctx, %v, %v)", constructor, str, vCtx)
+		nilErr = fmt.Sprintf("&cv.TypeError{ValidatorID: %q, FieldName: %v}", customValidator.ID, val.name)
+	case "len":
+		if !hasLen(typ) {
+			return "", unsupported
+		}
+		call = fmt.Sprintf("dv.ValidateLenValue(ctx, len(%v), %v)", x, vCtx)
+	case "min", "max":
+		size, ok := sizeOf(x, typ)
+		if !ok {
+			return "", unsupported
+		}
+		call = fmt.Sprintf("dv.Validate%vValue(ctx, %v, %v)", constructor, size, vCtx)
+	case "oneof":
+		str, ok := g.formatOf(x, typ)
+		if !ok {
+			return "", unsupported
+		}
+		call = fmt.Sprintf("dv.ValidateOneOfString(ctx, %v, %v)", str, vCtx)
+	}
+
+	if nilErr == "" && customValidator.ID != "non-nil" && customValidator.ID != "non-zero" && customValidator.ID != "required" {
+		nilErr = fmt.Sprintf("dv.%vErrorf(%q, %v)", constructor, customValidator.ID+" field %v is nil", val.name)
+	}
+
+	if isNil != "false" && nilErr != "" {
+		if strings.HasPrefix(nilErr, "&cv.") {
+			g.use(cvPath)
+		}
+		fmt.Fprintf(&sb, "if %v {\n%v = %v\n} else {\n%v = %v\n}\n", isNil, errVar, nilErr, errVar, call)
+	} else {
+		fmt.Fprintf(&sb, "%v = %v\n", errVar, call)
+	}
+
+	return sb.String(), nil
+}
+
+func (g *generator) generateAndOr(w *bytes.Buffer, node *validator.Node, val value) (string, error) {
+	left, right := node.Children[0], node.Children[1]
+	leftErr, err := g.generateNode(w, left, val)
+	if err != nil {
+		return "", err
+	}
+
+	rightErr, err := g.generateNode(w, right, val)
+	if err != nil {
+		return "", err
+	}
+
+	errVar := g.newVar("err")
+	fmt.Fprintf(w, "var %v error\n", errVar)
+	if node.Kind == validator.AndNode {
+		fmt.Fprintf(w, "if %v != nil || %v != nil {\n", leftErr, rightErr)
+		fmt.Fprintf(w, "%v = %v\n}\n", errVar, g.fieldError(fmt.Sprintf("&& validation of %v and %v of Field ", left.SubTag, right.SubTag), val.path, " failed"))
+	} else {
+		fmt.Fprintf(w, "if %v != nil && %v != nil {\n", leftErr, rightErr)
+		fmt.Fprintf(w, "%v = %v\n}\n", errVar, g.fieldError(fmt.Sprintf("|| validation of %v or %v of Field ", left.SubTag, right.SubTag), val.path, " failed"))
+	}
+
+	return errVar, nil
+}
+
+func (g *generator) generateNot(w *bytes.Buffer, node *validator.Node, val value) (string, error) {
+	operandErr, err := g.generateNode(w, node.Children[0], val)
+	if err != nil {
+		return "", err
+	}
+
+	errVar := g.newVar("err")
+	fmt.Fprintf(w, "var %v error\n", errVar)
+	fmt.Fprintf(w, "if %v == nil {\n", operandErr)
+	fmt.Fprintf(w, "%v = %v\n}\n", errVar, g.fieldError(fmt.Sprintf("validation of %v of Field ", node.SubTag), val.path, " failed"))
+
+	return errVar, nil
+}
+
+func (g *generator) generateIf(w *bytes.Buffer, node *validator.Node, val value) (string, error) {
+	conditionErr, err := g.generateNode(w, node.Children[0], val)
+	if err != nil {
+		return "", err
+	}
+
+	errVar := g.newVar("err")
+	fmt.Fprintf(w, "var %v error\n", errVar)
+	fmt.Fprintf(w, "if %v == nil {\n", conditionErr)
+	thenErr, err := g.generateNode(w, node.Children[1], val)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(w, "%v = %v\n", errVar, thenErr)
+
+	if len(node.Children) > 2 {
+		fmt.Fprintf(w, "} else {\n")
+		elseErr, err := g.generateNode(w, node.Children[2], val)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(w, "%v = %v\n", errVar, elseErr)
+	}
+	fmt.Fprintf(w, "}\n")
+
+	return errVar, nil
+}
+
+func (g *generator) generateDive(w *bytes.Buffer, node *validator.Node, val value) (string, error) {
+	errVar := g.newVar("err")
+	fmt.Fprintf(w, "var %v error\n", errVar)
+
+	typ := val.typ
+	expr := val.expr
+	ptr, isPtr := typ.Underlying().(*types.Pointer)
+	if isPtr {
+		typ = ptr.Elem()
+		expr = "*" + val.expr
+	}
+
+	var keyType, elemType types.Type
+	switch u := typ.Underlying().(type) {
+	case *types.Slice:
+		elemType = u.Elem()
+	case *types.Array:
+		elemType = u.Elem()
+	case *types.Map:
+		keyType, elemType = u.Key(), u.Elem()
+	default:
+		return "", fmt.Errorf("dive validation of fields of type %v is not supported", val.typ)
+	}
+
+	keyVar, elemVar, nameVar := g.newVar("key"), g.newVar("elem"), g.newVar("name")
+	var elem bytes.Buffer
+	elemErr, err := g.generateNode(&elem, node.Children[0], value{
+		expr:      elemVar,
+		typ:       elemType,
+		name:      nameVar,
+		path:      "prefix + " + nameVar,
+		fieldName: val.fieldName,
+		static:    false,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	usesName := usesIdent(elem.String(), nameVar)
+	if !usesIdent(elem.String(), elemVar) {
+		elemVar = "_"
+	}
+
+	if isPtr {
+		fmt.Fprintf(w, "if %v != nil {\n", val.expr)
+	}
+
+	switch {
+	case !usesName && elemVar == "_":
+		fmt.Fprintf(w, "for range %v {\n", expr)
+	case !usesName:
+		fmt.Fprintf(w, "for _, %v := range %v {\n", elemVar, expr)
+	case elemVar == "_":
+		fmt.Fprintf(w, "for %v := range %v {\n", keyVar, expr)
+	default:
+		fmt.Fprintf(w, "for %v, %v := range %v {\n", keyVar, elemVar, expr)
+	}
+
+	if usesName {
+		fmt.Fprintf(w, "%v := %v + \"[\" + %v + \"]\"\n", nameVar, val.name, g.formatKey(keyVar, keyType))
+	}
+	w.Write(elem.Bytes())
+	fmt.Fprintf(w, "if %v != nil {\n%v = %v\nbreak\n}\n}\n", elemErr, errVar, elemErr)
+
+	if isPtr {
+		fmt.Fprintf(w, "}\n")
+	}
+
+	return errVar, nil
+}
+
+// nilValidation returns the regex and the path of the first field of the struct failing on a nil pointer of the struct.
+// Returns an empty path if no field fails.
+func (g *generator) nilValidation(st *types.Struct, path string, seen map[*types.Struct]bool) (string, string, error) {
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		fieldPath := path + field.Name()
+
+		node, err := g.validator.CompileTag(validatorTag(st, i))
+		if err != nil {
+			return "", "", fmt.Errorf("field %v: %w", field.Name(), err)
+		}
+
+		for _, validation := range node.Validations() {
+			customValidators := validation.CustomValidators()
+			sort.Slice(customValidators, func(i, j int) bool {
+				return customValidators[i].ID < customValidators[j].ID
+			})
+
+			for _, customValidator := range customValidators {
+				if customValidator.Config.ShouldFailIfFieldOfNilPtr {
+					return customValidator.TagRegex.String(), fieldPath, nil
+				}
+			}
+		}
+
+		nested, ok := gotypes.Deref(field.Type()).Underlying().(*types.Struct)
+		if !ok || seen[nested] {
+			continue
+		}
+
+		seen[nested] = true
+		regex, nestedPath, err := g.nilValidation(nested, fieldPath+".", seen)
+		if err != nil || nestedPath != "" {
+			return regex, nestedPath, err
+		}
+	}
+
+	return "", "", nil
+}
+
+// validationContext returns the package variable containing the validation context of a sub tag for a field
+func (g *generator) validationContext(constructor string, subTag string, fieldName string) string {
+	key := strings.Join([]string{constructor, subTag, fieldName}, "\x00")
+	if name, ok := g.contextVars[key]; ok {
+		return name
+	}
+
+	name := fmt.Sprintf("validatorGenCtx%v", len(g.contextVars))
+	g.contextVars[key] = name
+	g.use(dvPath)
+	fmt.Fprintf(&g.contexts, "%v = validator.MustValidationContext(dv.%v(), %q, %q)\n", name, constructor, subTag, fieldName)
+
+	return name
+}
+
+// fieldError returns an expression creating an error with a message of the form before + path + after
+func (g *generator) fieldError(before, path, after string) string {
+	g.use("errors")
+	if after == "" {
+		return fmt.Sprintf("errors.New(%q + %v)", before, path)
+	}
+
+	return fmt.Sprintf("errors.New(%q + %v + %q)", before, path, after)
+}
+
+// zeroCheck returns an expression reporting whether x has its zero value the same way as reflect.Value.IsZero
+func (g *generator) zeroCheck(x string, typ types.Type) (string, bool) {
+	switch u := typ.Underlying().(type) {
+	case *types.Basic:
+		info := u.Info()
+		switch {
+		case info&types.IsString != 0:
+			return x + ` == ""`, true
+		case info&types.IsBoolean != 0:
+			return "!" + x, true
+		case info&types.IsFloat != 0:
+			// reflect does not consider negative zero as zero value
+			g.use("math")
+			return fmt.Sprintf("math.Float64bits(float64(%v)) == 0", x), true
+		case info&types.IsNumeric != 0:
+			return x + " == 0", true
+		case u.Kind() == types.UnsafePointer:
+			return x + " == nil", true
+		}
+	case *types.Slice, *types.Map, *types.Chan, *types.Signature:
+		return x + " == nil", true
+	case *types.Struct, *types.Array:
+		if types.Comparable(typ) {
+			return fmt.Sprintf("%v == (%v{})", x, types.TypeString(typ, g.qualifier)), true
+		}
+	}
+
+	return "", false
+}
+
+// formatOf returns an expression formatting x the same way as the oneof custom validator
+func (g *generator) formatOf(x string, typ types.Type) (string, bool) {
+	u, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return "", false
+	}
+
+	info := u.Info()
+	switch {
+	case info&types.IsString != 0:
+		return stringOf(x, typ)
+	case info&types.IsInteger != 0 && info&types.IsUnsigned != 0:
+		g.use("strconv")
+		return fmt.Sprintf("strconv.FormatUint(uint64(%v), 10)", x), true
+	case info&types.IsInteger != 0:
+		g.use("strconv")
+		return fmt.Sprintf("strconv.FormatInt(int64(%v), 10)", x), true
+	case info&types.IsFloat != 0:
+		g.use("strconv")
+		return fmt.Sprintf("strconv.FormatFloat(float64(%v), 'f', -1, 64)", x), true
+	case info&types.IsBoolean != 0:
+		g.use("strconv")
+		return fmt.Sprintf("strconv.FormatBool(bool(%v))", x), true
+	}
+
+	return "", false
+}
+
+// formatKey returns an expression formatting an index or map key the same way as fmt's %v verb
+func (g *generator) formatKey(key string, keyType types.Type) string {
+	if keyType == nil {
+		g.use("strconv")
+		return fmt.Sprintf("strconv.Itoa(%v)", key)
+	}
+
+	if u, ok := keyType.Underlying().(*types.Basic); ok && u.Info()&(types.IsFloat|types.IsComplex) == 0 {
+		if str, ok := g.formatOf(key, keyType); ok {
+			return str
+		}
+	}
+
+	g.use("fmt")
+	return fmt.Sprintf("fmt.Sprint(%v)", key)
+}
+
+func (g *generator) newVar(prefix string) string {
+	g.counter++
+	return fmt.Sprintf("%v%v", prefix, g.counter)
+}
+
+func (g *generator) use(path string) {
+	if _, ok := g.imports[path]; !ok {
+		g.imports[path] = ""
+	}
+}
+
+func (g *generator) qualifier(pkg *types.Package) string {
+	if pkg == g.pkg {
+		return ""
+	}
+
+	g.imports[pkg.Path()] = pkg.Name()
+	return pkg.Name()
+}
+
+// source assembles and formats the generated file
+func (g *generator) source() ([]byte, error) {
+	g.use("context")
+	g.use(validatorPath)
+
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	// standard library imports are sorted before all other imports
+	sort.Slice(paths, func(i, j int) bool {
+		if isStandardImport(paths[i]) != isStandardImport(paths[j]) {
+			return isStandardImport(paths[i])
+		}
+		return paths[i] < paths[j]
+	})
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by validator-gen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %v\n\n", g.pkg.Name())
+
+	fmt.Fprintf(&src, "import (\n")
+	for i, path := range paths {
+		if i > 0 && isStandardImport(paths[i-1]) && !isStandardImport(path) {
+			fmt.Fprintf(&src, "\n")
+		}
+		fmt.Fprintf(&src, "%q\n", path)
+	}
+	fmt.Fprintf(&src, ")\n\n")
+
+	if g.contexts.Len() > 0 {
+		fmt.Fprintf(&src, "var (\n")
+		src.Write(g.contexts.Bytes())
+		fmt.Fprintf(&src, ")\n\n")
+	}
+
+	fmt.Fprintf(&src, "func init() {\n")
+	src.Write(g.checks.Bytes())
+	fmt.Fprintf(&src, "}\n\n")
+	src.Write(g.body.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated code cannot be formatted: %w", err)
+	}
+
+	return formatted, nil
+}
+
+// Utility Methods
+
+func validatorTag(st *types.Struct, i int) string {
+	return reflect.StructTag(st.Tag(i)).Get(validator.DefaultTagKey)
+}
+
+func hasMethod(named *types.Named, name string) bool {
+	for i := 0; i < named.NumMethods(); i++ {
+		if named.Method(i).Name() == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasValidatorTags reports whether a struct type or any of its nested struct types contains a validator tag
+func hasValidatorTags(typ types.Type, seen map[types.Type]bool) bool {
+	st, ok := gotypes.Deref(typ).Underlying().(*types.Struct)
+	if !ok || seen[st] {
+		return false
+	}
+	seen[st] = true
+
+	for i := 0; i < st.NumFields(); i++ {
+		if validatorTag(st, i) != "" || hasValidatorTags(st.Field(i).Type(), seen) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stringOf returns an expression converting x to a string if its kind is string
+func stringOf(x string, typ types.Type) (string, bool) {
+	u, ok := typ.Underlying().(*types.Basic)
+	if !ok || u.Info()&types.IsString == 0 {
+		return "", false
+	}
+
+	if types.Identical(typ, types.Typ[types.String]) {
+		return x, true
+	}
+
+	return fmt.Sprintf("string(%v)", x), true
+}
+
+// sizeOf returns an expression of the size of x as used by the min and max custom validators
+func sizeOf(x string, typ types.Type) (string, bool) {
+	if hasLen(typ) {
+		return fmt.Sprintf("float64(len(%v))", x), true
+	}
+
+	u, ok := typ.Underlying().(*types.Basic)
+	if !ok || u.Info()&(types.IsInteger|types.IsFloat) == 0 {
+		return "", false
+	}
+
+	return fmt.Sprintf("float64(%v)", x), true
+}
+
+func hasLen(typ types.Type) bool {
+	switch u := typ.Underlying().(type) {
+	case *types.Basic:
+		return u.Info()&types.IsString != 0
+	case *types.Slice, *types.Array, *types.Map:
+		return true
+	}
+
+	return false
+}
+
+func isStandardImport(path string) bool {
+	return !strings.Contains(strings.Split(path, "/")[0], ".")
+}
+
+func usesIdent(code string, ident string) bool {
+	return regexp.MustCompile(`\b` + regexp.QuoteMeta(ident) + `\b`).MatchString(code)
+}
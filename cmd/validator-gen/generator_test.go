@@ -0,0 +1,111 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate_exampleIsUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..", "examples", "generated")
+
+	src, err := generate(dir, []string{"User"}, "user_validator.go")
+	assert.NoError(t, err)
+
+	generated, err := os.ReadFile(filepath.Join(dir, "user_validator.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, string(generated), string(src), "run go generate ./examples/generated")
+}
+
+func TestGenerate_allTaggedTypes(t *testing.T) {
+	dir := writePackage(t, "type Tagged struct {\n\tName string `validator:\"required\"`\n}\n\ntype Untagged struct {\n\tName string\n}\n")
+
+	src, err := generate(dir, nil, "validator_gen.go")
+	assert.NoError(t, err)
+	assert.Contains(t, string(src), "func (s *Tagged) Validate(ctx context.Context) error")
+	assert.NotContains(t, string(src), "Untagged")
+}
+
+func TestGenerate_errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       string
+		typeNames []string
+		err       string
+	}{
+		{
+			name:      "unknown type",
+			src:       "type User struct{}\n",
+			typeNames: []string{"Person"},
+			err:       "type Person not found in package example",
+		},
+		{
+			name:      "no struct type",
+			src:       "type Names []string\n",
+			typeNames: []string{"Names"},
+			err:       "type Names is not a struct type",
+		},
+		{
+			name: "no tagged types",
+			src:  "type User struct{}\n",
+			err:  "no struct types with validator tags found in package example",
+		},
+		{
+			name:      "invalid tag",
+			src:       "type User struct {\n\tName string `validator:\"required &&\"`\n}\n",
+			typeNames: []string{"User"},
+			err:       "type User: field Name: ",
+		},
+		{
+			name:      "unsupported kind",
+			src:       "type User struct {\n\tAge int `validator:\"len(3)\"`\n}\n",
+			typeNames: []string{"User"},
+			err:       "type User: field Age: custom validator len does not support fields of type int",
+		},
+		{
+			name:      "unsupported dive",
+			src:       "type User struct {\n\tAge int `validator:\"dive(required)\"`\n}\n",
+			typeNames: []string{"User"},
+			err:       "type User: field Age: dive validation of fields of type int is not supported",
+		},
+		{
+			name:      "inline message",
+			src:       "type User struct {\n\tName string `validator:\"len(9)#msg(username.length)\"`\n}\n",
+			typeNames: []string{"User"},
+			err:       "type User: field Name: message \"username.length\" of len(9) is not supported",
+		},
+		{
+			name:      "message tag",
+			src:       "type User struct {\n\tName string `validator:\"len(9)\" validator-msg:\"username.length\"`\n}\n",
+			typeNames: []string{"User"},
+			err:       "type User: field Name: validator-msg tags are not supported",
+		},
+		{
+			name:      "existing Validate method",
+			src:       "type User struct {\n\tName string `validator:\"required\"`\n}\n\nfunc (u *User) Validate() error { return nil }\n",
+			typeNames: []string{"User"},
+			err:       "type User already has a Validate method",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writePackage(t, tt.src)
+
+			_, err := generate(dir, tt.typeNames, "validator_gen.go")
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.err)
+			}
+		})
+	}
+}
+
+func writePackage(t *testing.T, src string) string {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "example.go"), []byte("package example\n\n"+src), 0o644)
+	assert.NoError(t, err)
+
+	return dir
+}
@@ -0,0 +1,66 @@
+// Command validator-gen generates reflection free Validate methods for structs from their validator tags.
+// The generated methods call the default validators of the dv package directly and return the same errors as the reflective Validator.
+// Usage:
+//
+//	//go:generate go run github.com/gogo-gadget/validator/cmd/validator-gen -type User
+//
+// Flags:
+//
+//	-type   comma separated list of struct types, defaults to all struct types with validator tags
+//	-output name of the generated file, defaults to <type>_validator.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("validator-gen: ")
+
+	typeNames := flag.String("type", "", "comma separated list of struct types, defaults to all struct types with validator tags")
+	output := flag.String("output", "", "name of the generated file, defaults to <type>_validator.go")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: validator-gen [flags] [directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	var types []string
+	if *typeNames != "" {
+		types = strings.Split(*typeNames, ",")
+	}
+
+	outputName := *output
+	if outputName == "" {
+		outputName = defaultOutputName(types)
+	}
+
+	src, err := generate(dir, types, outputName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, outputName), src, 0o644) //nolint:gosec // source files are readable like all other files of the package
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func defaultOutputName(types []string) string {
+	if len(types) == 0 {
+		return "validator_gen.go"
+	}
+
+	return fmt.Sprintf("%v_validator.go", strings.ToLower(types[0]))
+}
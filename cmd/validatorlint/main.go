@@ -0,0 +1,88 @@
+// Command validatorlint checks validator tags statically and prints file:line diagnostics.
+// Tags are checked against the tag grammar, the default custom validators and their parameter schemas
+// as well as against the kinds of the tagged fields. The exit code is 1 if any problem has been found.
+// Usage:
+//
+//	validatorlint [flags] [directories]
+//
+// Directories default to ./... which checks all packages of the current directory and its subdirectories.
+//
+// Flags:
+//
+//	-tags   comma separated list of tag keys, keys of the go-playground syntax are suffixed by :playground e.g. validator,validate:playground
+//	-tests  lint test files as well
+//	-ignore comma separated list of ids of custom validators and aliases that are not reported as unknown e.g. eq-field,username
+//
+// The command only knows the default custom validators, since custom validators and aliases are registered by the application at runtime.
+// Their ids can be ignored by the -ignore flag, which neither checks their arguments nor the kinds of the tagged fields.
+// To check them as well, run the linter of the lint package with the validator of the application e.g. in a test:
+//
+//	diagnostics, err := lint.New(app.NewValidator()).Run("./...")
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gogo-gadget/validator"
+	"github.com/gogo-gadget/validator/lint"
+)
+
+func main() {
+	tags := flag.String("tags", validator.DefaultTagKey, "comma separated list of tag keys, keys of the go-playground syntax are suffixed by :playground")
+	tests := flag.Bool("tests", false, "lint test files as well")
+	ignore := flag.String("ignore", "", "comma separated list of ids of custom validators and aliases that are not reported as unknown")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: validatorlint [flags] [directories]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	v := validator.NewValidator()
+	v.SetTagKeys(parseTagKeys(*tags)...)
+
+	linter := lint.New(v)
+	linter.Tests = *tests
+	if *ignore != "" {
+		linter.Ignore = strings.Split(*ignore, ",")
+	}
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	diagnostics, err := linter.Run(patterns...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "validatorlint: %v\n", err)
+		os.Exit(2)
+	}
+
+	for _, diagnostic := range diagnostics {
+		fmt.Println(diagnostic)
+	}
+
+	if len(diagnostics) > 0 {
+		os.Exit(1)
+	}
+}
+
+func parseTagKeys(tags string) []validator.TagKey {
+	var tagKeys []validator.TagKey
+	for _, tag := range strings.Split(tags, ",") {
+		name, syntax := tag, ""
+		if i := strings.Index(tag, ":"); i >= 0 {
+			name, syntax = tag[:i], tag[i+1:]
+		}
+
+		if syntax == "playground" {
+			tagKeys = append(tagKeys, validator.PlaygroundTagKey(name))
+		} else {
+			tagKeys = append(tagKeys, validator.NativeTagKey(name))
+		}
+	}
+
+	return tagKeys
+}
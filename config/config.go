@@ -0,0 +1,389 @@
+// Package config loads the configuration of a service from environment variables, flags and default values into a struct,
+// validates it and reports every setting with its source.
+// Usage:
+//
+//	type Config struct {
+//		Port     int    `env:"PORT" flag:"port" default:"8080" validator:"min(1)"`
+//		Password string `env:"DB_PASSWORD" secret:"true" validator:"required"`
+//	}
+//
+//	var cfg Config
+//	loader := config.NewLoader(validator.NewValidator(), "APP_", flag.CommandLine)
+//	config.DefineFlags(flag.CommandLine, &cfg)
+//	flag.Parse()
+//	report, err := loader.Load(ctx, &cfg)
+//	report.WriteTo(os.Stderr)
+package config
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/gogo-gadget/validator"
+	"github.com/gogo-gadget/validator/internal/convert"
+)
+
+// The struct field tag keys configuring the settings of a struct
+const (
+	// EnvTagKey contains the name of the environment variable of a field without the prefix of the loader
+	EnvTagKey = "env"
+	// EnvPrefixTagKey contains the prefix of the environment variables of the fields of a nested struct e.g. DB_
+	EnvPrefixTagKey = "envPrefix"
+	// FlagTagKey contains the name of the flag of a field
+	FlagTagKey = "flag"
+	// DefaultTagKey contains the default value of a field, which is the same tag that is applied by validator.ApplyDefaults
+	DefaultTagKey = validator.DefaultValueTagKey
+	// SecretTagKey marks a field whose value is masked in reports and errors e.g. secret:"true"
+	SecretTagKey = "secret"
+	// UsageTagKey contains the usage of the flag of a field that is defined by DefineFlags
+	UsageTagKey = "usage"
+)
+
+// TypeValidator is the validator ID of field errors of values that cannot be converted to the type of their field
+const TypeValidator = "type"
+
+// DefaultSeparator separates the elements of slices in environment variables and flags
+const DefaultSeparator = ","
+
+// Source is the source of the value of a setting
+type Source string
+
+// The sources of the values of settings ordered by their precedence
+const (
+	SourceFlag    Source = "flag"
+	SourceEnv     Source = "env"
+	SourceDefault Source = "default"
+	// SourceUnset is the source of settings without value
+	SourceUnset Source = "unset"
+)
+
+// Loader loads the configuration of a service into a struct.
+// Flags that have been set take precedence over environment variables, which take precedence over default values.
+type Loader struct {
+	// Validator validates the loaded configuration. If nil, a validator with the default custom validators is used.
+	Validator *validator.Validator
+	// Prefix is prepended to the names of all environment variables e.g. APP_
+	Prefix string
+	// FlagSet contains the parsed flags. If nil, no values are loaded from flags.
+	FlagSet *flag.FlagSet
+	// LookupEnv returns the value of an environment variable. If nil, os.LookupEnv is used.
+	LookupEnv func(key string) (string, bool)
+	// Separator separates the elements of slices in environment variables and flags. If empty, DefaultSeparator is used.
+	// The elements of default values are always separated by validator.DefaultValueSeparator.
+	Separator string
+}
+
+// NewLoader creates a loader that reads environment variables with the prefix from the environment of the process
+// and flags from the flag set
+func NewLoader(v *validator.Validator, prefix string, fs *flag.FlagSet) *Loader {
+	return &Loader{
+		Validator: v,
+		Prefix:    prefix,
+		FlagSet:   fs,
+	}
+}
+
+// Load fills the struct the provided pointer points to from flags, environment variables and default values and validates it.
+// The report lists every setting with its source and error and is returned unless the pointer does not point to a struct.
+// Returns ValidationErrors containing the conversion errors followed by the validation errors of all other fields, any other error
+// e.g. a validator.TagSyntaxError or nil if the configuration is valid.
+func (l *Loader) Load(ctx context.Context, ptr interface{}) (*Report, error) {
+	structValue, err := structOf(ptr)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &loading{
+		loader:     l,
+		flags:      map[string]string{},
+		flagValues: map[string][]string{},
+		report:     &Report{},
+	}
+	if l.FlagSet != nil {
+		l.FlagSet.Visit(func(f *flag.Flag) {
+			if ff, ok := f.Value.(*fieldFlag); ok {
+				s.flags[f.Name] = strings.Join(ff.values, l.separator())
+				s.flagValues[f.Name] = ff.values
+				return
+			}
+			s.flags[f.Name] = f.Value.String()
+		})
+	}
+
+	s.loadStruct(structValue, "", l.Prefix)
+	if s.err != nil {
+		return s.report, s.err
+	}
+
+	v := l.Validator
+	if v == nil {
+		v = validator.NewValidator()
+	}
+
+	errs := s.errs
+	if locale, ok := validator.LocaleFromContext(ctx); ok && len(errs) > 0 {
+		errs = v.Translate(errs, locale).(validator.ValidationErrors)
+		for i, fieldErr := range errs {
+			s.report.setting(fieldErr.Path).Err = errs[i]
+		}
+	}
+
+	err = v.ValidateAll(ctx, ptr)
+	var validationErrs validator.ValidationErrors
+	if err != nil && !errors.As(err, &validationErrs) {
+		return s.report, err
+	}
+
+	for _, fieldErr := range validationErrs {
+		setting := s.report.setting(fieldErr.Path)
+		if setting == nil {
+			s.report.Settings = append(s.report.Settings, Setting{Path: fieldErr.Path, Source: SourceUnset, Err: fieldErr})
+			errs = append(errs, fieldErr)
+			continue
+		}
+
+		// settings whose values cannot be converted are only reported once
+		if setting.Err != nil {
+			continue
+		}
+
+		if setting.Secret {
+			fieldErr = maskFieldError(ctx, v, fieldErr)
+		}
+		setting.Err = fieldErr
+		errs = append(errs, fieldErr)
+	}
+
+	if len(errs) == 0 {
+		return s.report, nil
+	}
+
+	return s.report, errs
+}
+
+// loading contains the state of a call of Load
+type loading struct {
+	loader *Loader
+	// flags contains the values of the flags that have been set by their names
+	flags map[string]string
+	// flagValues contains the values of the flags defined by DefineFlags that have been set by their names,
+	// which are loaded as they are without splitting them by the separator
+	flagValues map[string][]string
+	report     *Report
+	errs       validator.ValidationErrors
+	err        error
+	// structTypes contains the types of the structs that are currently loaded, whose nil pointers must not be allocated
+	structTypes []reflect.Type
+}
+
+// loadStruct loads the settings of a struct and its nested structs.
+// Returns true if any setting has a value.
+func (s *loading) loadStruct(structValue reflect.Value, path string, envPrefix string) bool {
+	loaded := false
+
+	structType := structValue.Type()
+	s.structTypes = append(s.structTypes, structType)
+	defer func() { s.structTypes = s.structTypes[:len(s.structTypes)-1] }()
+
+	for i := 0; i < structType.NumField() && s.err == nil; i++ {
+		structField := structType.Field(i)
+		fieldValue := structValue.Field(i)
+		fieldPath := structField.Name
+		if path != "" {
+			fieldPath = path + "." + structField.Name
+		}
+
+		if !isSetting(structField) {
+			loaded = s.loadNested(fieldValue, fieldPath, envPrefix+structField.Tag.Get(EnvPrefixTagKey)) || loaded
+			continue
+		}
+
+		if !fieldValue.CanSet() {
+			continue
+		}
+
+		if !convert.IsSupported(structField.Type) {
+			s.err = fmt.Errorf("field %v: loading of type %v is not supported", fieldPath, structField.Type)
+			return loaded
+		}
+
+		setting := Setting{
+			Path:   fieldPath,
+			Source: SourceUnset,
+			Secret: isSecret(structField),
+		}
+		if env, ok := structField.Tag.Lookup(EnvTagKey); ok && env != "-" {
+			setting.Env = envPrefix + env
+		}
+		if name, ok := structField.Tag.Lookup(FlagTagKey); ok && name != "-" {
+			setting.Flag = name
+		}
+
+		raw := s.lookup(structField, &setting)
+		if setting.Source != SourceUnset {
+			loaded = true
+			setting.Value = raw
+			if setting.Secret {
+				setting.Value = mask(raw)
+			}
+
+			values, ok := s.flagValues[setting.Flag]
+			if setting.Source != SourceFlag || !ok {
+				values = convert.Split(fieldValue.Type(), raw, s.separator(setting.Source))
+			}
+
+			err := convert.SetValues(fieldValue, values)
+			if err != nil {
+				fieldErr := conversionError(structField, fieldPath, setting.Secret, err)
+				setting.Err = fieldErr
+				s.errs = append(s.errs, fieldErr)
+			}
+		}
+
+		s.report.Settings = append(s.report.Settings, setting)
+	}
+
+	return loaded
+}
+
+// loadNested loads the settings of an untagged field of kind struct or pointer to struct.
+// Nil pointers are only set if any setting of the nested struct has a value, unless the nested struct type is currently loaded.
+func (s *loading) loadNested(fieldValue reflect.Value, path string, envPrefix string) bool {
+	fieldType := fieldValue.Type()
+	allocate := fieldType.Kind() == reflect.Ptr && !containsType(s.structTypes, fieldType.Elem())
+
+	loaded, _ := convert.FillStruct(fieldValue, allocate, func(structValue reflect.Value) (bool, error) {
+		return s.loadStruct(structValue, path, envPrefix), nil
+	})
+	return loaded
+}
+
+// lookup returns the value of a setting by the precedence of its sources and sets the source of the setting
+func (s *loading) lookup(structField reflect.StructField, setting *Setting) string {
+	if setting.Flag != "" {
+		if raw, ok := s.flags[setting.Flag]; ok {
+			setting.Source = SourceFlag
+			return raw
+		}
+	}
+
+	if setting.Env != "" {
+		lookupEnv := s.loader.LookupEnv
+		if lookupEnv == nil {
+			lookupEnv = os.LookupEnv
+		}
+
+		if raw, ok := lookupEnv(setting.Env); ok {
+			setting.Source = SourceEnv
+			return raw
+		}
+	}
+
+	if raw, ok := structField.Tag.Lookup(DefaultTagKey); ok {
+		setting.Source = SourceDefault
+		return raw
+	}
+
+	return ""
+}
+
+// separator returns the separator of the elements of slices in values of the source
+func (s *loading) separator(source Source) string {
+	if source == SourceDefault {
+		return validator.DefaultValueSeparator
+	}
+
+	return s.loader.separator()
+}
+
+func (l *Loader) separator() string {
+	if l.Separator == "" {
+		return DefaultSeparator
+	}
+
+	return l.Separator
+}
+
+// isSetting returns whether a field is loaded from an environment variable, flag or default value
+func isSetting(structField reflect.StructField) bool {
+	for _, tagKey := range []string{EnvTagKey, FlagTagKey, DefaultTagKey} {
+		if _, ok := structField.Tag.Lookup(tagKey); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSecret(structField reflect.StructField) bool {
+	secret, ok := structField.Tag.Lookup(SecretTagKey)
+	return ok && secret != "false"
+}
+
+// conversionError creates the field error of a value that cannot be converted to the type of its field
+func conversionError(structField reflect.StructField, path string, secret bool, err error) *validator.FieldError {
+	label := structField.Name
+	if tagLabel, ok := structField.Tag.Lookup(validator.LabelTagKey); ok {
+		label = tagLabel
+	}
+
+	var convertErr *convert.Error
+	errors.As(err, &convertErr)
+	convertErr.Path = path
+	if secret {
+		convertErr.Value = mask(convertErr.Value)
+	}
+
+	fieldErr := validator.NewFieldError(path, label, TypeValidator, convertErr)
+	fieldErr.Params = []string{convertErr.Type.String()}
+	fieldErr.Value = convertErr.Value
+	fieldErr.Kind = convertErr.Type.Kind()
+
+	return fieldErr
+}
+
+// maskFieldError replaces the validation error of a secret field by an error without its value.
+// The message is rebuilt by the catalog of the locale of the context or the english catalog, since the messages
+// of custom validators and their causes may contain the value. Errors without message template keep the message
+// of their tag or are reported as invalid.
+func maskFieldError(ctx context.Context, v *validator.Validator, fieldErr *validator.FieldError) *validator.FieldError {
+	locale, ok := validator.LocaleFromContext(ctx)
+	if !ok {
+		locale = "en"
+	}
+
+	masked := *fieldErr
+	masked.Value = nil
+	masked.Err = nil
+	message := fmt.Sprintf("%v is invalid", fieldErr.Label)
+	if fieldErr.MessageKey != "" {
+		// messages of tags are written by the developer and do not contain the value
+		message = fieldErr.MessageKey
+	}
+	if translated, ok := v.Translate(&masked, locale).(*validator.FieldError); ok && translated != &masked {
+		message = translated.Error()
+	}
+
+	maskedErr := validator.NewFieldError(fieldErr.Path, fieldErr.Label, fieldErr.Validator, errors.New(message))
+	maskedErr.SubTag = fieldErr.SubTag
+	maskedErr.Params = fieldErr.Params
+	maskedErr.Kind = fieldErr.Kind
+	maskedErr.MessageKey = fieldErr.MessageKey
+
+	return maskedErr
+}
+
+// structOf returns the struct the provided pointer points to
+func structOf(ptr interface{}) (reflect.Value, error) {
+	ptrValue := reflect.ValueOf(ptr)
+	if ptrValue.Kind() != reflect.Ptr || ptrValue.IsNil() || ptrValue.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("configuration requires a non-nil pointer to a struct but got %T", ptr)
+	}
+
+	return ptrValue.Elem(), nil
+}
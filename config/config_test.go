@@ -0,0 +1,239 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator"
+)
+
+type serviceConfig struct {
+	Name    string        `env:"NAME" validator:"required"`
+	Port    int           `env:"PORT" flag:"port" default:"8080" validator:"min(1)"`
+	Debug   bool          `flag:"debug" usage:"enables debug logging"`
+	Timeout time.Duration `env:"TIMEOUT" default:"5s"`
+	Hosts   []string      `env:"HOSTS" flag:"host" validator:"dive(min(3))"`
+	DB      *dbConfig     `envPrefix:"DB_"`
+}
+
+type dbConfig struct {
+	User     string `env:"USER" default:"admin"`
+	Password string `env:"PASSWORD" secret:"true" validator:"len(8)"`
+}
+
+func env(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func TestLoader_Load(t *testing.T) {
+	fs := flag.NewFlagSet("service", flag.ContinueOnError)
+	var cfg serviceConfig
+	assert.NoError(t, DefineFlags(fs, &cfg))
+	assert.NoError(t, fs.Parse([]string{"-port", "9090", "-debug", "-host", "foo.com", "-host", "bar.com"}))
+
+	loader := NewLoader(validator.NewValidator(), "APP_", fs)
+	loader.LookupEnv = env(map[string]string{
+		"APP_NAME":        "service",
+		"APP_PORT":        "7070",
+		"APP_HOSTS":       "ignored.com",
+		"APP_DB_PASSWORD": "12345678",
+	})
+
+	report, err := loader.Load(context.Background(), &cfg)
+	assert.NoError(t, err)
+	assert.True(t, report.Valid())
+
+	assert.Equal(t, serviceConfig{
+		Name:    "service",
+		Port:    9090,
+		Debug:   true,
+		Timeout: 5 * time.Second,
+		Hosts:   []string{"foo.com", "bar.com"},
+		DB:      &dbConfig{User: "admin", Password: "12345678"},
+	}, cfg)
+
+	assert.Equal(t, []Setting{
+		{Path: "Name", Env: "APP_NAME", Source: SourceEnv, Value: "service"},
+		{Path: "Port", Env: "APP_PORT", Flag: "port", Source: SourceFlag, Value: "9090"},
+		{Path: "Debug", Flag: "debug", Source: SourceFlag, Value: "true"},
+		{Path: "Timeout", Env: "APP_TIMEOUT", Source: SourceDefault, Value: "5s"},
+		{Path: "Hosts", Env: "APP_HOSTS", Flag: "host", Source: SourceFlag, Value: "foo.com,bar.com"},
+		{Path: "DB.User", Env: "APP_DB_USER", Source: SourceDefault, Value: "admin"},
+		{Path: "DB.Password", Env: "APP_DB_PASSWORD", Source: SourceEnv, Value: Mask, Secret: true},
+	}, report.Settings)
+
+	assert.Equal(t, "enables debug logging", fs.Lookup("debug").Usage)
+}
+
+func TestLoader_Load_reportsInvalidSettings(t *testing.T) {
+	loader := NewLoader(nil, "", nil)
+	loader.LookupEnv = env(map[string]string{
+		"PORT":        "http",
+		"HOSTS":       "foo.com,x",
+		"DB_PASSWORD": "secret",
+	})
+
+	var cfg serviceConfig
+	report, err := loader.Load(context.Background(), &cfg)
+
+	var errs validator.ValidationErrors
+	if assert.True(t, errors.As(err, &errs)) && assert.Len(t, errs, 4) {
+		assert.Equal(t, "Port", errs[0].Path)
+		assert.Equal(t, TypeValidator, errs[0].Validator)
+		assert.Equal(t, "Name", errs[1].Path)
+		assert.Equal(t, "Hosts[1]", errs[2].Path)
+		assert.Equal(t, "DB.Password", errs[3].Path)
+		assert.Nil(t, errs[3].Value)
+	}
+
+	assert.False(t, report.Valid())
+	invalid := report.Invalid()
+	if assert.Len(t, invalid, 4) {
+		assert.Equal(t, SourceUnset, invalid[0].Source)
+		assert.Equal(t, "Port", invalid[1].Path)
+		assert.Equal(t, "Hosts", invalid[2].Path)
+		assert.Equal(t, Mask, invalid[3].Value)
+	}
+
+	expected := `SETTING      ENV          FLAG    SOURCE   VALUE      ERROR
+Name         NAME                 unset               non-zero field Name has zero value
+Port         PORT         -port   env      http       type field Port has value "http", but should be a valid int
+Debug                     -debug  unset
+Timeout      TIMEOUT              default  5s
+Hosts        HOSTS        -host   env      foo.com,x  min field Hosts[1] has size 1, but should have at least size 3
+DB.User      DB_USER              default  admin
+DB.Password  DB_PASSWORD          env      ******     Password must have exactly 8 characters
+`
+	assert.Equal(t, expected, report.String())
+}
+
+func TestLoader_Load_masksSecretConversionErrors(t *testing.T) {
+	type secretConfig struct {
+		Token int `env:"TOKEN" secret:"true"`
+	}
+
+	loader := NewLoader(nil, "", nil)
+	loader.LookupEnv = env(map[string]string{"TOKEN": "s3cr3t"})
+
+	_, err := loader.Load(validator.WithLocale(context.Background(), "de"), &secretConfig{})
+	assert.EqualError(t, err, "Token muss ein gültiger Wert vom Typ int sein")
+
+	_, err = loader.Load(context.Background(), &secretConfig{})
+	assert.EqualError(t, err, `type field Token has value "******", but should be a valid int`)
+}
+
+func TestLoader_Load_masksSecretValidationErrors(t *testing.T) {
+	type secretConfig struct {
+		Mode   string   `env:"MODE" secret:"true" validator:"oneof('a','b')"`
+		Key    string   `env:"KEY" secret:"true" validator:"len(3) && regex('^k')"`
+		Tokens []string `env:"TOKENS" secret:"true" validator:"dive(oneof('a'))"`
+		Phrase string   `env:"PHRASE" secret:"true" validator:"(regex('^p') || len(3))#msg('invalid phrase')"`
+	}
+
+	loader := NewLoader(nil, "", nil)
+	loader.LookupEnv = env(map[string]string{"MODE": "hunter2", "KEY": "hunter3", "TOKENS": "hunter4", "PHRASE": "hunter5"})
+
+	report, err := loader.Load(context.Background(), &secretConfig{})
+	assert.EqualError(t, err, "Mode must be one of a, b; Key is invalid; Tokens[0] must be one of a; invalid phrase")
+	assert.NotContains(t, report.String(), "hunter")
+
+	var errs validator.ValidationErrors
+	if assert.True(t, errors.As(err, &errs)) {
+		for _, fieldErr := range errs {
+			assert.Nil(t, fieldErr.Value)
+			assert.NotContains(t, errors.Unwrap(fieldErr).Error(), "hunter")
+		}
+	}
+
+	report, err = loader.Load(validator.WithLocale(context.Background(), "de"), &secretConfig{})
+	assert.Contains(t, err.Error(), "Mode muss einer der Werte a, b sein")
+	assert.NotContains(t, err.Error(), "hunter")
+	assert.NotContains(t, report.String(), "hunter")
+}
+
+func TestLoader_Load_unsupported(t *testing.T) {
+	loader := NewLoader(nil, "", nil)
+
+	_, err := loader.Load(context.Background(), serviceConfig{})
+	assert.EqualError(t, err, "configuration requires a non-nil pointer to a struct but got config.serviceConfig")
+
+	type unsupported struct {
+		Labels map[string]string `env:"LABELS"`
+	}
+	_, err = loader.Load(context.Background(), &unsupported{})
+	assert.EqualError(t, err, "field Labels: loading of type map[string]string is not supported")
+}
+
+type defaultsConfig struct {
+	Tags []string `env:"TAGS" default:"a,b"`
+	Node *nodeConfig
+}
+
+type nodeConfig struct {
+	Name string `env:"NAME" default:"root"`
+	Next *nodeConfig
+}
+
+func TestLoader_Load_appliesDefaultsLikeApplyDefaults(t *testing.T) {
+	loader := NewLoader(validator.NewValidator(), "", nil)
+	loader.Separator = ";"
+	loader.LookupEnv = env(map[string]string{})
+
+	var loaded defaultsConfig
+	_, err := loader.Load(context.Background(), &loaded)
+	assert.NoError(t, err)
+
+	var applied defaultsConfig
+	assert.NoError(t, validator.NewValidator().ApplyDefaults(&applied))
+
+	// default tags are split by validator.DefaultValueSeparator and recursive nil pointers are not allocated
+	assert.Equal(t, defaultsConfig{Tags: []string{"a", "b"}, Node: &nodeConfig{Name: "root"}}, loaded)
+	assert.Equal(t, applied, loaded)
+
+	loader.LookupEnv = env(map[string]string{"TAGS": "c,d;e"})
+	loaded = defaultsConfig{}
+	_, err = loader.Load(context.Background(), &loaded)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c,d", "e"}, loaded.Tags)
+}
+
+func TestLoader_Load_keepsSeparatorsInSliceFlags(t *testing.T) {
+	type tagsConfig struct {
+		Tags []string `flag:"tags"`
+	}
+
+	fs := flag.NewFlagSet("service", flag.ContinueOnError)
+	var cfg tagsConfig
+	assert.NoError(t, DefineFlags(fs, &cfg))
+	assert.NoError(t, fs.Parse([]string{"-tags", "a,b", "-tags", "c"}))
+
+	_, err := NewLoader(validator.NewValidator(), "", fs).Load(context.Background(), &cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a,b", "c"}, cfg.Tags)
+}
+
+type listenerConfig struct {
+	Port int `flag:"port"`
+}
+
+func TestDefineFlags_nestedStructs(t *testing.T) {
+	fs := flag.NewFlagSet("service", flag.ContinueOnError)
+	// nested structs of a type containing them are skipped
+	assert.NoError(t, DefineFlags(fs, &nodeConfig{}))
+
+	type listeners struct {
+		Public   listenerConfig
+		Internal *listenerConfig
+	}
+	fs = flag.NewFlagSet("service", flag.ContinueOnError)
+	err := DefineFlags(fs, &listeners{})
+	assert.EqualError(t, err, "field Internal.Port: flag -port is already defined")
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gogo-gadget/validator/internal/convert"
+)
+
+// DefineFlags defines a flag on the flag set for every field of the struct the provided pointer points to that has a flag tag.
+// The usage of a flag is the usage tag of its field. Flags of boolean fields can be set without value and flags of slice fields
+// can be set multiple times. Load reads the values of the flags that have been set by parsing the flag set.
+// Nested structs of a type that contains them are skipped. Returns an error if a flag is already defined on the flag set
+// e.g. by two nested structs of the same type.
+func DefineFlags(fs *flag.FlagSet, ptr interface{}) error {
+	structValue, err := structOf(ptr)
+	if err != nil {
+		return err
+	}
+
+	return defineFlags(fs, structValue.Type(), "", nil)
+}
+
+// defineFlags defines the flags of a struct type and its nested struct types.
+// The struct types contain the types of the structs whose flags are currently defined, which are not defined again.
+func defineFlags(fs *flag.FlagSet, structType reflect.Type, path string, structTypes []reflect.Type) error {
+	structTypes = append(structTypes, structType)
+
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		fieldPath := structField.Name
+		if path != "" {
+			fieldPath = path + "." + structField.Name
+		}
+
+		fieldType := structField.Type
+		for fieldType.Kind() == reflect.Ptr && !convert.IsScalar(fieldType) {
+			fieldType = fieldType.Elem()
+		}
+
+		name, ok := structField.Tag.Lookup(FlagTagKey)
+		if !ok || name == "-" {
+			if !isSetting(structField) && fieldType.Kind() == reflect.Struct && !convert.IsScalar(fieldType) && !containsType(structTypes, fieldType) {
+				err := defineFlags(fs, fieldType, fieldPath, structTypes)
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if fs.Lookup(name) != nil {
+			return fmt.Errorf("field %v: flag -%v is already defined", fieldPath, name)
+		}
+
+		fs.Var(&fieldFlag{
+			boolean: fieldType.Kind() == reflect.Bool,
+			slice:   structField.Type.Kind() == reflect.Slice && !convert.IsScalar(structField.Type),
+		}, name, structField.Tag.Get(UsageTagKey))
+	}
+
+	return nil
+}
+
+// containsType returns whether the struct types contain the type
+func containsType(structTypes []reflect.Type, rType reflect.Type) bool {
+	for _, structType := range structTypes {
+		if structType == rType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fieldFlag is the flag of a field, which keeps its value as string until it is loaded
+type fieldFlag struct {
+	values  []string
+	boolean bool
+	slice   bool
+}
+
+// String returns the values of the flag separated by DefaultSeparator
+// Implements flag.Value interface
+func (f *fieldFlag) String() string {
+	return strings.Join(f.values, DefaultSeparator)
+}
+
+// Set sets the value of the flag or adds the value to the values of a slice flag
+// Implements flag.Value interface
+func (f *fieldFlag) Set(value string) error {
+	if f.slice {
+		f.values = append(f.values, value)
+	} else {
+		f.values = []string{value}
+	}
+
+	return nil
+}
+
+// IsBoolFlag returns whether the flag can be set without value
+func (f *fieldFlag) IsBoolFlag() bool {
+	return f.boolean
+}
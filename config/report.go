@@ -0,0 +1,125 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gogo-gadget/validator"
+)
+
+// Mask replaces the values of secret settings in reports and errors
+const Mask = "******"
+
+// Report lists the settings of a configuration with their sources and errors
+type Report struct {
+	Settings []Setting
+}
+
+// Setting describes a field of a configuration
+type Setting struct {
+	// Path is the full name of the field e.g. DB.Password
+	Path string
+	// Env is the name of the environment variable including the prefixes or empty if the field has no env tag
+	Env string
+	// Flag is the name of the flag or empty if the field has no flag tag
+	Flag string
+	// Source is the source of the value of the field
+	Source Source
+	// Value is the loaded value of the field, which is masked for secret fields
+	Value string
+	// Secret marks fields whose values are masked
+	Secret bool
+	// Err is the conversion or validation error of the field or nil if the field is valid
+	Err *validator.FieldError
+}
+
+// Valid returns whether all settings are valid
+func (r *Report) Valid() bool {
+	for _, setting := range r.Settings {
+		if setting.Err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Invalid returns the settings that failed their conversion or validation including missing settings
+func (r *Report) Invalid() []Setting {
+	var invalid []Setting
+	for _, setting := range r.Settings {
+		if setting.Err != nil {
+			invalid = append(invalid, setting)
+		}
+	}
+
+	return invalid
+}
+
+// WriteTo writes the report as table containing a row per setting with its environment variable, flag, source, value and error.
+// Implements io.WriterTo interface
+func (r *Report) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "SETTING\tENV\tFLAG\tSOURCE\tVALUE\tERROR")
+	for _, setting := range r.Settings {
+		flagName := ""
+		if setting.Flag != "" {
+			flagName = "-" + setting.Flag
+		}
+
+		errMessage := ""
+		if setting.Err != nil {
+			errMessage = setting.Err.Error()
+		}
+
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\n", setting.Path, setting.Env, flagName, setting.Source, setting.Value, errMessage)
+	}
+
+	err := tw.Flush()
+	if err != nil {
+		return 0, err
+	}
+
+	// the columns of empty cells at the end of the rows are padded as well
+	lines := strings.Split(buf.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+
+	n, err := io.WriteString(w, strings.Join(lines, "\n"))
+	return int64(n), err
+}
+
+// String returns the report as table
+func (r *Report) String() string {
+	var builder strings.Builder
+	_, _ = r.WriteTo(&builder)
+
+	return builder.String()
+}
+
+// setting returns the setting of a field or the setting of a slice or map field containing the element of the path e.g. Hosts for Hosts[0]
+func (r *Report) setting(path string) *Setting {
+	for i := range r.Settings {
+		setting := &r.Settings[i]
+		if setting.Path == path || strings.HasPrefix(path, setting.Path+"[") {
+			return setting
+		}
+	}
+
+	return nil
+}
+
+// mask masks the non-empty value of a secret setting
+func mask(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return Mask
+}
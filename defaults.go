@@ -0,0 +1,133 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gogo-gadget/validator/internal/convert"
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// DefaultValueTagKey is the struct field tag key containing the default value of a field that is set by ApplyDefaults
+const DefaultValueTagKey = "default"
+
+// DefaultValueSeparator separates the elements of default values of slice fields e.g. `default:"a,b"`
+const DefaultValueSeparator = ","
+
+// ApplyDefaults sets the zero-valued fields of the struct the provided pointer points to from their default tags,
+// so that the validation afterwards sees the default values. Supported are strings, booleans, numbers, durations, types implementing
+// encoding.TextUnmarshaler e.g. time.Time as well as pointers to and slices of them. The elements of slices are separated by commas.
+// Nil pointers to nested structs are allocated if any field of the nested struct has a default value, unless the nested
+// struct type contains the field itself. The config package applies default tags the same way.
+// Returns an error if a default value cannot be converted to the type of its field.
+func (v *Validator) ApplyDefaults(i interface{}) error {
+	ptrValue := reflect.ValueOf(i)
+	if ptrValue.Kind() != reflect.Ptr || ptrValue.IsNil() || ptrValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("applying defaults requires a non-nil pointer to a struct but got %T", i)
+	}
+
+	_, err := applyStructDefaults(ptrValue.Elem(), nil)
+	return err
+}
+
+// applyStructDefaults sets the default values of the fields of a struct and its nested structs.
+// Returns true if any default value has been set.
+func applyStructDefaults(structValue reflect.Value, parent *cv.Field) (bool, error) {
+	applied := false
+
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		fieldValue := structValue.Field(i)
+
+		field := &cv.Field{
+			Parent:      parent,
+			StructField: structField,
+			Value:       fieldValue,
+			Struct:      structValue,
+		}
+
+		defaultValue, ok := structField.Tag.Lookup(DefaultValueTagKey)
+		if !ok {
+			nestedApplied, err := applyNestedDefaults(fieldValue, field)
+			if err != nil {
+				return applied, err
+			}
+			applied = applied || nestedApplied
+			continue
+		}
+
+		if !fieldValue.CanSet() || !fieldValue.IsZero() {
+			continue
+		}
+
+		if !convert.IsSupported(structField.Type) {
+			return applied, fmt.Errorf("default value of Field %v of type %v is not supported", getFullFieldName(field), structField.Type)
+		}
+
+		err := convert.SetValues(fieldValue, convert.Split(structField.Type, defaultValue, DefaultValueSeparator))
+		if err != nil {
+			return applied, fmt.Errorf("default value %q of Field %v cannot be converted to %v: %w",
+				defaultValue, getFullFieldName(field), structField.Type, errorCause(err))
+		}
+		applied = true
+	}
+
+	return applied, nil
+}
+
+// applyNestedDefaults sets the default values of an untagged field of kind struct or pointer to struct and of the struct elements of slices and arrays.
+// Nil pointers are only allocated if any default value has been set.
+func applyNestedDefaults(fieldValue reflect.Value, field *cv.Field) (bool, error) {
+	fieldType := fieldValue.Type()
+	if convert.IsScalar(fieldType) {
+		return false, nil
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Struct, reflect.Ptr:
+		allocate := fieldType.Kind() == reflect.Ptr && !isRecursive(field, fieldType.Elem())
+		return convert.FillStruct(fieldValue, allocate, func(structValue reflect.Value) (bool, error) {
+			return applyStructDefaults(structValue, field)
+		})
+	case reflect.Slice, reflect.Array:
+		applied := false
+		for i := 0; i < fieldValue.Len(); i++ {
+			elem := fieldValue.Index(i)
+			if elem.Kind() == reflect.Ptr && elem.IsNil() {
+				// nil elements are not allocated since they are no optional fields
+				continue
+			}
+
+			elemApplied, err := applyNestedDefaults(elem, elemField(field, i, elem))
+			if err != nil {
+				return applied, err
+			}
+			applied = applied || elemApplied
+		}
+
+		return applied, nil
+	}
+
+	return false, nil
+}
+
+// isRecursive returns whether a struct type contains the field or a parent of it, whose nil pointers must not be allocated
+func isRecursive(field *cv.Field, structType reflect.Type) bool {
+	for ; field != nil; field = field.Parent {
+		if field.Struct.Type() == structType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// errorCause returns the error of the conversion of a convert.Error
+func errorCause(err error) error {
+	if convertErr, ok := err.(*convert.Error); ok {
+		return convertErr.Err
+	}
+
+	return err
+}
@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type DefaultsServer struct {
+	Host      string        `default:"localhost"`
+	Port      int           `default:"8080"`
+	Debug     bool          `default:"true"`
+	Timeout   time.Duration `default:"1m30s"`
+	Ratio     *float64      `default:"0.5"`
+	Tags      []string      `default:"a,b"`
+	TLS       *DefaultsTLS
+	Backends  []DefaultsBackend
+	Next      *DefaultsServer
+	NoDefault *DefaultsNoDefault
+}
+
+type DefaultsTLS struct {
+	MinVersion string `default:"1.2"`
+}
+
+type DefaultsBackend struct {
+	Weight int `default:"1"`
+}
+
+type DefaultsNoDefault struct {
+	Name string
+}
+
+func TestValidator_ApplyDefaults(t *testing.T) {
+	validator := NewValidator()
+
+	server := DefaultsServer{Port: 9090, Backends: []DefaultsBackend{{}, {Weight: 3}}}
+	err := validator.ApplyDefaults(&server)
+	assert.NoError(t, err)
+
+	ratio := 0.5
+	assert.Equal(t, DefaultsServer{
+		Host:     "localhost",
+		Port:     9090,
+		Debug:    true,
+		Timeout:  90 * time.Second,
+		Ratio:    &ratio,
+		Tags:     []string{"a", "b"},
+		TLS:      &DefaultsTLS{MinVersion: "1.2"},
+		Backends: []DefaultsBackend{{Weight: 1}, {Weight: 3}},
+	}, server)
+
+}
+
+func TestValidator_ApplyDefaults_beforeValidation(t *testing.T) {
+	validator := NewValidator()
+
+	type user struct {
+		Role string `default:"user" validator:"required && oneof(admin,user)"`
+	}
+
+	u := user{}
+	assert.Error(t, validator.Validate(context.Background(), u))
+	assert.NoError(t, validator.ApplyDefaults(&u))
+	assert.NoError(t, validator.Validate(context.Background(), u))
+}
+
+func TestValidator_ApplyDefaults_errors(t *testing.T) {
+	validator := NewValidator()
+
+	err := validator.ApplyDefaults(DefaultsServer{})
+	assert.EqualError(t, err, "applying defaults requires a non-nil pointer to a struct but got validator.DefaultsServer")
+
+	type invalidDefault struct {
+		Nested struct {
+			Port int `default:"http"`
+		}
+	}
+	err = validator.ApplyDefaults(&invalidDefault{})
+	assert.EqualError(t, err, `default value "http" of Field Nested.Port cannot be converted to int: strconv.ParseInt: parsing "http": invalid syntax`)
+
+	type unsupportedDefault struct {
+		Labels map[string]string `default:"a"`
+	}
+	err = validator.ApplyDefaults(&unsupportedDefault{})
+	assert.EqualError(t, err, "default value of Field Labels of type map[string]string is not supported")
+}
@@ -0,0 +1,182 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// TypeDescription describes the validation rules of a struct type
+type TypeDescription struct {
+	// Type is the Go type of the struct e.g. models.User
+	Type   string              `json:"type"`
+	Fields []*FieldDescription `json:"fields"`
+}
+
+// FieldDescription describes the validation rules of a struct field and the fields of its nested struct
+type FieldDescription struct {
+	// Path is the full name of the field as used in validation errors e.g. Address.Street
+	Path string `json:"path"`
+	Name string `json:"name"`
+	// Type is the Go type of the field e.g. *models.Address
+	Type string `json:"type"`
+	// Tags contains the source of the validation tags of the field by their tag keys
+	Tags map[string]string `json:"tags,omitempty"`
+	// Rule is the compiled expression tree of all tags or nil if the field is not validated by tags
+	Rule *RuleDescription `json:"rule,omitempty"`
+	// Fields describes the fields of the struct the field contains or points to
+	Fields []*FieldDescription `json:"fields,omitempty"`
+	// Ref is the type of a struct that is already described by a parent field, which is referenced to describe recursive types
+	Ref string `json:"ref,omitempty"`
+}
+
+// RuleDescription describes a node of a compiled expression tree
+type RuleDescription struct {
+	Kind   NodeKind `json:"kind"`
+	SubTag string   `json:"subTag"`
+	// Validators contains the custom validators matching the sub tag of a validation node
+	Validators []*ValidatorDescription `json:"validators,omitempty"`
+	Children   []*RuleDescription      `json:"children,omitempty"`
+	// Message replaces the message of the error of the node
+	Message string `json:"message,omitempty"`
+}
+
+// ValidatorDescription describes a custom validator that is run for a sub tag together with its arguments
+type ValidatorDescription struct {
+	ID string `json:"id"`
+	// Name is the name of the sub tag e.g. len for len(9)
+	Name string                 `json:"name"`
+	Args []*ArgumentDescription `json:"args,omitempty"`
+}
+
+// ArgumentDescription describes an argument of a sub tag and the parameter it is passed to
+type ArgumentDescription struct {
+	Param string       `json:"param,omitempty"`
+	Type  cv.ParamType `json:"type"`
+	// Value is the argument as written in the tag
+	Value string `json:"value"`
+}
+
+// Describe returns the validation rules of the fields of a struct type and its nested structs
+// as they are compiled from the tags by the configured tag keys, aliases, macros and custom validators.
+// The description does not depend on any value and can be serialized by encoding/json.
+// Returns a TagSyntaxError if a tag cannot be compiled.
+// Usage:
+//
+//	description, err := validator.Describe(reflect.TypeOf(User{}))
+func (v *Validator) Describe(rType reflect.Type) (*TypeDescription, error) {
+	if rType == nil {
+		return nil, fmt.Errorf("description of a nil type is not supported")
+	}
+
+	rType = getUnderlyingType(rType)
+	if rType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("description of kind %v is not supported", rType.Kind())
+	}
+
+	fields, err := v.describeStruct(rType, "", map[reflect.Type]bool{rType: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TypeDescription{
+		Type:   rType.String(),
+		Fields: fields,
+	}, nil
+}
+
+// describeStruct describes the fields of a struct type while parents contains the struct types of all parent fields
+func (v *Validator) describeStruct(structType reflect.Type, path string, parents map[reflect.Type]bool) ([]*FieldDescription, error) {
+	fields := []*FieldDescription{}
+
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+
+		field := &FieldDescription{
+			Path: path + structField.Name,
+			Name: structField.Name,
+			Type: structField.Type.String(),
+		}
+
+		for _, tagKey := range v.tagKeys() {
+			tag, ok := structField.Tag.Lookup(tagKey.Name)
+			if !ok {
+				continue
+			}
+
+			if field.Tags == nil {
+				field.Tags = map[string]string{}
+			}
+			field.Tags[tagKey.Name] = tag
+		}
+
+		node, err := v.compileField(structType, structField)
+		if err != nil {
+			return nil, withPath(err, field.Path)
+		}
+		field.Rule = describeNode(node)
+
+		fieldType := getUnderlyingType(structField.Type)
+		if fieldType.Kind() == reflect.Struct {
+			if parents[fieldType] {
+				field.Ref = fieldType.String()
+			} else {
+				parents[fieldType] = true
+				field.Fields, err = v.describeStruct(fieldType, field.Path+".", parents)
+				delete(parents, fieldType)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+func describeNode(node *Node) *RuleDescription {
+	if node == nil {
+		return nil
+	}
+
+	rule := &RuleDescription{
+		Kind:    node.Kind,
+		SubTag:  node.SubTag,
+		Message: node.Message,
+	}
+
+	for _, b := range node.bindings {
+		validator := &ValidatorDescription{
+			ID:   b.customValidator.ID,
+			Name: b.validationCtx.Name,
+		}
+
+		params := b.customValidator.Params
+		for i, arg := range b.validationCtx.Args {
+			argument := &ArgumentDescription{
+				Type:  arg.Type,
+				Value: arg.Raw,
+			}
+
+			// arguments exceeding the parameters are passed to the last variadic parameter
+			if i < len(params) {
+				argument.Param = params[i].Name
+			} else if len(params) > 0 {
+				argument.Param = params[len(params)-1].Name
+			}
+
+			validator.Args = append(validator.Args, argument)
+		}
+
+		rule.Validators = append(rule.Validators, validator)
+	}
+
+	for _, child := range node.Children {
+		rule.Children = append(rule.Children, describeNode(child))
+	}
+
+	return rule
+}
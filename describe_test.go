@@ -0,0 +1,116 @@
+package validator
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type DescribeUser struct {
+	Name    string           `validator:"required && len(9)"`
+	Role    string           `validator:"oneof(admin,user)" validate:"required"`
+	Address *DescribeAddress `validator:"non-nil"`
+	Parent  *DescribeUser
+}
+
+type DescribeAddress struct {
+	Zip int `validator:"if(non-zero)then(min(1000))"`
+}
+
+func TestValidator_Describe(t *testing.T) {
+	validator := NewValidator()
+	validator.SetTagKeys(NativeTagKey("validator"), PlaygroundTagKey("validate"))
+
+	description, err := validator.Describe(reflect.TypeOf(&DescribeUser{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	data, err := json.Marshal(description)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "validator.DescribeUser",
+		"fields": [
+			{
+				"path": "Name",
+				"name": "Name",
+				"type": "string",
+				"tags": {"validator": "required && len(9)"},
+				"rule": {
+					"kind": "and",
+					"subTag": "required&&len(9)",
+					"children": [
+						{"kind": "validation", "subTag": "required", "validators": [{"id": "required", "name": "required"}]},
+						{"kind": "validation", "subTag": "len(9)", "validators": [{"id": "len", "name": "len", "args": [{"param": "length", "type": "int", "value": "9"}]}]}
+					]
+				}
+			},
+			{
+				"path": "Role",
+				"name": "Role",
+				"type": "string",
+				"tags": {"validator": "oneof(admin,user)", "validate": "required"},
+				"rule": {
+					"kind": "and",
+					"subTag": "oneof(admin,user)&&required",
+					"children": [
+						{"kind": "validation", "subTag": "oneof(admin,user)", "validators": [{"id": "oneof", "name": "oneof", "args": [
+							{"param": "values", "type": "string", "value": "admin"},
+							{"param": "values", "type": "string", "value": "user"}
+						]}]},
+						{"kind": "validation", "subTag": "required", "validators": [{"id": "required", "name": "required"}]}
+					]
+				}
+			},
+			{
+				"path": "Address",
+				"name": "Address",
+				"type": "*validator.DescribeAddress",
+				"tags": {"validator": "non-nil"},
+				"rule": {"kind": "validation", "subTag": "non-nil", "validators": [{"id": "non-nil", "name": "non-nil"}]},
+				"fields": [
+					{
+						"path": "Address.Zip",
+						"name": "Zip",
+						"type": "int",
+						"tags": {"validator": "if(non-zero)then(min(1000))"},
+						"rule": {
+							"kind": "if",
+							"subTag": "if(non-zero)then(min(1000))",
+							"children": [
+								{"kind": "validation", "subTag": "non-zero", "validators": [{"id": "non-zero", "name": "non-zero"}]},
+								{"kind": "validation", "subTag": "min(1000)", "validators": [{"id": "min", "name": "min", "args": [{"param": "min", "type": "float", "value": "1000"}]}]}
+							]
+						}
+					}
+				]
+			},
+			{
+				"path": "Parent",
+				"name": "Parent",
+				"type": "*validator.DescribeUser",
+				"ref": "validator.DescribeUser"
+			}
+		]
+	}`, string(data))
+}
+
+func TestValidator_Describe_fails(t *testing.T) {
+	validator := NewValidator()
+
+	_, err := validator.Describe(nil)
+	assert.EqualError(t, err, "description of a nil type is not supported")
+
+	_, err = validator.Describe(reflect.TypeOf(""))
+	assert.EqualError(t, err, "description of kind string is not supported")
+
+	type Invalid struct {
+		Address struct {
+			Zip int `validator:"min(1"`
+		}
+	}
+	_, err = validator.Describe(reflect.TypeOf(Invalid{}))
+	assert.EqualError(t, err, "missing closing bracket: map[field-path:Address.Zip position:5 tag:min(1]")
+}
@@ -3,7 +3,6 @@ package dv
 import (
 	"context"
 	"fmt"
-	"reflect"
 	"regexp"
 
 	"github.com/gogo-gadget/validator/pkg/cv"
@@ -28,43 +27,31 @@ func (err EmailError) Error() string {
 	return string(err)
 }
 
+var emailRegex = regexp.MustCompile(EmailRegexString)
+
 // Email creates a new email custom validator
 func Email() *cv.CustomValidator {
 	emailTagString := "email"
 	emailTagRegex := regexp.MustCompile(emailTagString)
 
-	customValidator := cv.NewCustomValidator("email", emailTagRegex, ValidateEmail, cv.NewCustomValidatorConfig().FailForNilValue())
-	return customValidator
+	customValidator := cv.NewTyped("email", emailTagRegex, ValidateEmailString, cv.NewCustomValidatorConfig().FailForNilValue(), []cv.Param{}...)
+	return customValidator.WithSchema(emailSchema).WithDescription("be a valid e-mail address")
 }
 
 // ValidateEmail is a custom validation function for the email custom validator
 func ValidateEmail(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
-	value := f.Value
-	kind := value.Kind()
-
-	for kind == reflect.Interface || kind == reflect.Ptr || kind == reflect.UnsafePointer {
-		if f.Value.IsNil() {
-			return EmailErrorf("email field %v is nil", f.StructField.Name)
-		}
-
-		value = value.Elem()
-		kind = value.Kind()
-	}
-
-	if kind != reflect.String {
-		return EmailErrorf("email field %v cannot be converted to string", f.StructField.Name)
-	}
+	return cv.Typed("email", ValidateEmailString)(ctx, f, vCtx)
+}
 
-	if value.IsZero() {
-		return EmailErrorf("email field %v has zero value", f.StructField.Name)
+// ValidateEmailString is a typed validation function for the email custom validator
+func ValidateEmailString(ctx context.Context, email string, vCtx *cv.ValidationContext) error {
+	if email == "" {
+		return EmailErrorf("email field %v has zero value", vCtx.FieldName)
 	}
 
-	emailRegex := regexp.MustCompile(EmailRegexString)
-	email := value.String()
-
 	isEmail := emailRegex.MatchString(email)
 	if !isEmail {
-		return EmailErrorf("email field %v is no valid email", f.StructField.Name)
+		return EmailErrorf("email field %v is no valid email", vCtx.FieldName)
 	}
 
 	return nil
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
-	"strconv"
 
 	"github.com/gogo-gadget/validator/pkg/cv"
 )
@@ -24,28 +23,28 @@ func (err LenError) Error() string {
 	return string(err)
 }
 
+// lengthKinds are the kinds of values that have a length
+var lengthKinds = []reflect.Kind{reflect.Map, reflect.Array, reflect.Slice, reflect.String}
+
+// lenParams are the parameters of the len custom validator
+var lenParams = []cv.Param{cv.IntParam("length")}
+
 // Len creates a new len custom validator
 func Len() *cv.CustomValidator {
-	lenTagString := `len\([1-9][0-9]*\)`
+	lenTagString := `len\(.*\)`
 	lenTagRegex := regexp.MustCompile(lenTagString)
 
-	customValidator := cv.NewCustomValidator("len", lenTagRegex, ValidateLen, cv.NewCustomValidatorConfig().FailForNilValue())
-	return customValidator
+	customValidator := cv.NewCustomValidator("len", lenTagRegex, ValidateLen, cv.NewCustomValidatorConfig().FailForNilValue().ForKinds(lengthKinds...), lenParams...)
+	return customValidator.WithSchema(lenSchema).WithDescription("{{if .Unit}}have exactly {{index .Values 0}} {{.Unit}}{{else}}have a length of {{index .Values 0}}{{end}}")
 }
 
 // ValidateLen is a custom validation function for the len custom validator
 func ValidateLen(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
-	value := f.Value
-	kind := value.Kind()
-
-	for kind == reflect.Interface || kind == reflect.Ptr || kind == reflect.UnsafePointer {
-		if f.Value.IsNil() {
-			return LenErrorf("len field %v is nil", f.StructField.Name)
-		}
-
-		value = value.Elem()
-		kind = value.Kind()
+	value, ok := cv.Deref(f.Value)
+	if !ok {
+		return LenErrorf("len field %v is nil", f.StructField.Name)
 	}
+	kind := value.Kind()
 
 	var length int
 	switch kind {
@@ -55,13 +54,19 @@ func ValidateLen(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) e
 		return LenErrorf("len field %v is of kind %v", f.StructField.Name, kind.String())
 	}
 
-	tagLength, err := strconv.Atoi(vCtx.SubTag[4 : len(vCtx.SubTag)-1])
+	return ValidateLenValue(ctx, length, vCtx.ForField(f))
+}
+
+// ValidateLenValue is a reflection free validation function for the len custom validator receiving the length of the field
+func ValidateLenValue(ctx context.Context, length int, vCtx *cv.ValidationContext) error {
+	parsed, err := vCtx.ParseArgs(lenParams...)
 	if err != nil {
-		return err
+		return LenErrorf("len field %v: %v", vCtx.FieldName, err)
 	}
+	tagLength := parsed.Arg(0).Int()
 
 	if length != tagLength {
-		return LenErrorf("len field %v has length %v, but should have length %v", f.StructField.Name, length, tagLength)
+		return LenErrorf("len field %v has length %v, but should have length %v", vCtx.FieldName, length, tagLength)
 	}
 
 	return nil
@@ -83,3 +83,13 @@ func TestValidateLen_failsForInvalidKinds(t *testing.T) {
 		})
 	}
 }
+
+func TestLen_failsForInvalidArguments(t *testing.T) {
+	for _, subTag := range []string{"len()", "len(abc)", "len(1,2)", "len(1.5)"} {
+		t.Run(subTag, func(t *testing.T) {
+			_, err := Len().NewValidationContext(subTag)
+
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package dv
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// MaxError is a custom error that will be returned by the max custom validator
+type MaxError string
+
+// MaxErrorf creates a new max error by providing a format string and optional parameters
+func MaxErrorf(format string, a ...interface{}) MaxError {
+	return MaxError(fmt.Sprintf(format, a...))
+}
+
+// Error returns the error message string
+// Implements error interface
+func (err MaxError) Error() string {
+	return string(err)
+}
+
+// maxParams are the parameters of the max custom validator
+var maxParams = []cv.Param{cv.FloatParam("max")}
+
+// Max creates a new max custom validator
+// Numbers must be less than or equal to the parameter, strings, maps, arrays and slices must have at most its length.
+func Max() *cv.CustomValidator {
+	maxTagString := `max\(.*\)`
+	maxTagRegex := regexp.MustCompile(maxTagString)
+
+	customValidator := cv.NewCustomValidator("max", maxTagRegex, ValidateMax, cv.NewCustomValidatorConfig().FailForNilValue().ForKinds(sizeKinds...), maxParams...)
+	return customValidator.WithSchema(maxSchema).WithDescription("{{if .Unit}}have at most {{index .Values 0}} {{.Unit}}{{else}}be at most {{index .Values 0}}{{end}}")
+}
+
+// ValidateMax is a custom validation function for the max custom validator
+func ValidateMax(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
+	value, ok := cv.Deref(f.Value)
+	if !ok {
+		return MaxErrorf("max field %v is nil", f.StructField.Name)
+	}
+	kind := value.Kind()
+
+	size, ok := sizeOf(value)
+	if !ok {
+		return MaxErrorf("max field %v is of kind %v", f.StructField.Name, kind.String())
+	}
+
+	return ValidateMaxValue(ctx, size, vCtx.ForField(f))
+}
+
+// ValidateMaxValue is a reflection free validation function for the max custom validator receiving the number or length of the field
+func ValidateMaxValue(ctx context.Context, size float64, vCtx *cv.ValidationContext) error {
+	parsed, err := vCtx.ParseArgs(maxParams...)
+	if err != nil {
+		return MaxErrorf("max field %v: %v", vCtx.FieldName, err)
+	}
+	tagMax := parsed.Arg(0).Float()
+
+	if size > tagMax {
+		return MaxErrorf("max field %v has size %v, but should have at most size %v", vCtx.FieldName, size, tagMax)
+	}
+
+	return nil
+}
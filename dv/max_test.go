@@ -0,0 +1,95 @@
+package dv
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+func TestValidateMax(t *testing.T) {
+	values := []sizeTest{
+		{
+			name:   "int",
+			value:  reflect.ValueOf(3),
+			subTag: "max(3)",
+		},
+		{
+			name:   "uint",
+			value:  reflect.ValueOf(uint(1)),
+			subTag: "max(1.5)",
+		},
+		{
+			name:   "string",
+			value:  reflect.ValueOf("abc"),
+			subTag: "max(3)",
+		},
+		{
+			name:   "map",
+			value:  reflect.ValueOf(map[string]int{}),
+			subTag: "max(0)",
+		},
+	}
+
+	for _, test := range values {
+		t.Run(test.name, func(t *testing.T) {
+			f := &cv.Field{
+				Value: test.value,
+			}
+
+			vCtx, err := Max().NewValidationContext(test.subTag)
+			assert.NoError(t, err)
+
+			err = ValidateMax(context.Background(), f, vCtx)
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateMax_fails(t *testing.T) {
+	values := []sizeTest{
+		{
+			name:   "int",
+			value:  reflect.ValueOf(4),
+			subTag: "max(3)",
+		},
+		{
+			name:   "slice",
+			value:  reflect.ValueOf([]string{"a", "b"}),
+			subTag: "max(1)",
+		},
+		{
+			name:   "bool",
+			value:  reflect.ValueOf(true),
+			subTag: "max(1)",
+		},
+	}
+
+	for _, test := range values {
+		t.Run(test.name, func(t *testing.T) {
+			f := &cv.Field{
+				Value: test.value,
+			}
+
+			vCtx, err := Max().NewValidationContext(test.subTag)
+			assert.NoError(t, err)
+
+			err = ValidateMax(context.Background(), f, vCtx)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValidateMaxValue_parsesSubTag(t *testing.T) {
+	assert.NoError(t, ValidateMaxValue(context.Background(), 3, &cv.ValidationContext{SubTag: "max(3)"}))
+
+	err := ValidateMaxValue(context.Background(), 4, &cv.ValidationContext{SubTag: "max(3)", FieldName: "Count"})
+	assert.EqualError(t, err, "max field Count has size 4, but should have at most size 3")
+
+	assert.Error(t, ValidateMaxValue(context.Background(), 2, &cv.ValidationContext{SubTag: "max()"}))
+}
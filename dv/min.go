@@ -0,0 +1,95 @@
+package dv
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// MinError is a custom error that will be returned by the min custom validator
+type MinError string
+
+// MinErrorf creates a new min error by providing a format string and optional parameters
+func MinErrorf(format string, a ...interface{}) MinError {
+	return MinError(fmt.Sprintf(format, a...))
+}
+
+// Error returns the error message string
+// Implements error interface
+func (err MinError) Error() string {
+	return string(err)
+}
+
+// numberKinds are the kinds of integer and floating point numbers
+var numberKinds = []reflect.Kind{
+	reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+	reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+	reflect.Float32, reflect.Float64,
+}
+
+// sizeKinds are the kinds of values supported by sizeOf
+var sizeKinds = append(append([]reflect.Kind{}, numberKinds...), lengthKinds...)
+
+// minParams are the parameters of the min custom validator
+var minParams = []cv.Param{cv.FloatParam("min")}
+
+// Min creates a new min custom validator
+// Numbers must be greater than or equal to the parameter, strings, maps, arrays and slices must have at least its length.
+func Min() *cv.CustomValidator {
+	minTagString := `min\(.*\)`
+	minTagRegex := regexp.MustCompile(minTagString)
+
+	customValidator := cv.NewCustomValidator("min", minTagRegex, ValidateMin, cv.NewCustomValidatorConfig().FailForNilValue().ForKinds(sizeKinds...), minParams...)
+	return customValidator.WithSchema(minSchema).WithDescription("{{if .Unit}}have at least {{index .Values 0}} {{.Unit}}{{else}}be at least {{index .Values 0}}{{end}}")
+}
+
+// ValidateMin is a custom validation function for the min custom validator
+func ValidateMin(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
+	value, ok := cv.Deref(f.Value)
+	if !ok {
+		return MinErrorf("min field %v is nil", f.StructField.Name)
+	}
+	kind := value.Kind()
+
+	size, ok := sizeOf(value)
+	if !ok {
+		return MinErrorf("min field %v is of kind %v", f.StructField.Name, kind.String())
+	}
+
+	return ValidateMinValue(ctx, size, vCtx.ForField(f))
+}
+
+// ValidateMinValue is a reflection free validation function for the min custom validator receiving the number or length of the field
+func ValidateMinValue(ctx context.Context, size float64, vCtx *cv.ValidationContext) error {
+	parsed, err := vCtx.ParseArgs(minParams...)
+	if err != nil {
+		return MinErrorf("min field %v: %v", vCtx.FieldName, err)
+	}
+	tagMin := parsed.Arg(0).Float()
+
+	if size < tagMin {
+		return MinErrorf("min field %v has size %v, but should have at least size %v", vCtx.FieldName, size, tagMin)
+	}
+
+	return nil
+}
+
+// sizeOf returns the number for numeric values or the length of strings, maps, arrays and slices.
+// Returns false if the size of a value of its kind cannot be determined.
+func sizeOf(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	case reflect.Map, reflect.Array, reflect.Slice, reflect.String:
+		return float64(value.Len()), true
+	}
+
+	return 0, false
+}
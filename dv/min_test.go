@@ -0,0 +1,113 @@
+package dv
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+type sizeTest struct {
+	name   string
+	value  reflect.Value
+	subTag string
+}
+
+func TestValidateMin(t *testing.T) {
+	stringVal := "0123456789"
+
+	values := []sizeTest{
+		{
+			name:   "int",
+			value:  reflect.ValueOf(3),
+			subTag: "min(3)",
+		},
+		{
+			name:   "float",
+			value:  reflect.ValueOf(0.5),
+			subTag: "min(0.25)",
+		},
+		{
+			name:   "negative",
+			value:  reflect.ValueOf(int8(-1)),
+			subTag: "min(-2)",
+		},
+		{
+			name:   "string",
+			value:  reflect.ValueOf(stringVal),
+			subTag: "min(10)",
+		},
+		{
+			name:   "pointer",
+			value:  reflect.ValueOf(&stringVal),
+			subTag: "min(1)",
+		},
+		{
+			name:   "slice",
+			value:  reflect.ValueOf([]int{1, 2}),
+			subTag: "min(2)",
+		},
+	}
+
+	for _, test := range values {
+		t.Run(test.name, func(t *testing.T) {
+			f := &cv.Field{
+				Value: test.value,
+			}
+
+			vCtx, err := Min().NewValidationContext(test.subTag)
+			assert.NoError(t, err)
+
+			err = ValidateMin(context.Background(), f, vCtx)
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateMin_fails(t *testing.T) {
+	values := []sizeTest{
+		{
+			name:   "int",
+			value:  reflect.ValueOf(2),
+			subTag: "min(3)",
+		},
+		{
+			name:   "string",
+			value:  reflect.ValueOf("abc"),
+			subTag: "min(4)",
+		},
+		{
+			name:   "struct",
+			value:  reflect.ValueOf(struct{}{}),
+			subTag: "min(1)",
+		},
+	}
+
+	for _, test := range values {
+		t.Run(test.name, func(t *testing.T) {
+			f := &cv.Field{
+				Value: test.value,
+			}
+
+			vCtx, err := Min().NewValidationContext(test.subTag)
+			assert.NoError(t, err)
+
+			err = ValidateMin(context.Background(), f, vCtx)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValidateMinValue_parsesSubTag(t *testing.T) {
+	assert.NoError(t, ValidateMinValue(context.Background(), 3, &cv.ValidationContext{SubTag: "min(3)"}))
+
+	err := ValidateMinValue(context.Background(), 2, &cv.ValidationContext{SubTag: "min(3)", FieldName: "Count"})
+	assert.EqualError(t, err, "min field Count has size 2, but should have at least size 3")
+
+	assert.Error(t, ValidateMinValue(context.Background(), 2, &cv.ValidationContext{SubTag: "min(abc)"}))
+}
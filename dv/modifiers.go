@@ -0,0 +1,83 @@
+package dv
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// Trim creates a new modifier that removes leading and trailing white space
+func Trim() *cv.Modifier {
+	return cv.NewModifier("trim", func(ctx context.Context, value string) string {
+		return strings.TrimSpace(value)
+	})
+}
+
+// Lower creates a new modifier that converts all letters to lower case
+func Lower() *cv.Modifier {
+	return cv.NewModifier("lower", func(ctx context.Context, value string) string {
+		return strings.ToLower(value)
+	})
+}
+
+// Upper creates a new modifier that converts all letters to upper case
+func Upper() *cv.Modifier {
+	return cv.NewModifier("upper", func(ctx context.Context, value string) string {
+		return strings.ToUpper(value)
+	})
+}
+
+// Title creates a new modifier that converts the first letter of every word to upper case and all other letters to lower case
+func Title() *cv.Modifier {
+	return cv.NewModifier("title", func(ctx context.Context, value string) string {
+		return cases.Title(language.Und).String(value)
+	})
+}
+
+// CollapseSpaces creates a new modifier that replaces every sequence of white space by a single space.
+// Leading and trailing white space is collapsed as well, but not removed.
+func CollapseSpaces() *cv.Modifier {
+	return cv.NewModifier("collapse", func(ctx context.Context, value string) string {
+		var builder strings.Builder
+		space := false
+		for _, r := range value {
+			if unicode.IsSpace(r) {
+				if !space {
+					builder.WriteByte(' ')
+				}
+				space = true
+				continue
+			}
+
+			space = false
+			builder.WriteRune(r)
+		}
+
+		return builder.String()
+	})
+}
+
+// NFC creates a new modifier that converts a string to the unicode normalization form C
+func NFC() *cv.Modifier {
+	return cv.NewModifier("nfc", func(ctx context.Context, value string) string {
+		return norm.NFC.String(value)
+	})
+}
+
+// StripControl creates a new modifier that removes all control characters e.g. null bytes and line breaks
+func StripControl() *cv.Modifier {
+	return cv.NewModifier("strip-control", func(ctx context.Context, value string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsControl(r) {
+				return -1
+			}
+			return r
+		}, value)
+	})
+}
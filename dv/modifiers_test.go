@@ -0,0 +1,33 @@
+package dv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+func TestModifiers(t *testing.T) {
+	tests := []struct {
+		modifier *cv.Modifier
+		value    string
+		expected string
+	}{
+		{Trim(), " \t a b \n", "a b"},
+		{Lower(), "ÄbC", "äbc"},
+		{Upper(), "äbc", "ÄBC"},
+		{Title(), "hELLO wORLD", "Hello World"},
+		{CollapseSpaces(), "  a \t\n b  ", " a b "},
+		{CollapseSpaces(), "a b", "a b"},
+		{NFC(), "e\u0301", "\u00e9"},
+		{StripControl(), "a\x00b\tc\u200bd\u0085", "abc\u200bd"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.modifier.ID, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.modifier.Modify(context.Background(), test.value))
+		})
+	}
+}
@@ -3,7 +3,6 @@ package dv
 import (
 	"context"
 	"fmt"
-	"reflect"
 	"regexp"
 
 	"github.com/gogo-gadget/validator/pkg/cv"
@@ -28,22 +27,21 @@ func NonNil() *cv.CustomValidator {
 	nonNilTagString := "non-nil"
 	nonNilTagRegexp := regexp.MustCompile(nonNilTagString)
 
-	customValidator := cv.NewCustomValidator("non-nil", nonNilTagRegexp, ValidateNonNil, cv.NewCustomValidatorConfig().FailForNilValue())
-	return customValidator
+	customValidator := cv.NewCustomValidator("non-nil", nonNilTagRegexp, ValidateNonNil, cv.NewCustomValidatorConfig().FailForNilValue(), []cv.Param{}...)
+	return customValidator.WithSchema(nonNilSchema).WithDescription("be set")
 }
 
 // ValidateNonNil is a custom validation function for the non-nil custom validator
 func ValidateNonNil(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
-	value := f.Value
-	kind := value.Kind()
-
-	for kind == reflect.Interface || kind == reflect.Ptr || kind == reflect.UnsafePointer {
-		if f.Value.IsNil() {
-			return NilErrorf("non-nil field %v is nil", f.StructField.Name)
-		}
+	_, ok := cv.Deref(f.Value)
+	return ValidateNonNilValue(ctx, !ok, vCtx.ForField(f))
+}
 
-		value = value.Elem()
-		kind = value.Kind()
+// ValidateNonNilValue is a reflection free validation function for the non-nil custom validator.
+// isNil reports whether the field or any pointer it points to is nil.
+func ValidateNonNilValue(ctx context.Context, isNil bool, vCtx *cv.ValidationContext) error {
+	if isNil {
+		return NilErrorf("non-nil field %v is nil", vCtx.FieldName)
 	}
 
 	return nil
@@ -3,7 +3,6 @@ package dv
 import (
 	"context"
 	"fmt"
-	"reflect"
 	"regexp"
 
 	"github.com/gogo-gadget/validator/pkg/cv"
@@ -23,31 +22,33 @@ func (err ZeroError) Error() string {
 	return string(err)
 }
 
+// nonZeroDescription describes the non-zero and the required custom validator
+const nonZeroDescription = `{{if .Unit}}not be empty{{else if eq .Kind "bool"}}be true{{else}}not be zero{{end}}`
+
 // NonZero creates a new non-zero custom validator
 func NonZero() *cv.CustomValidator {
 	nonZeroTagString := "non-zero"
 	nonZeroTagRegexp := regexp.MustCompile(nonZeroTagString)
 
-	customValidator := cv.NewCustomValidator("non-zero", nonZeroTagRegexp, ValidateNonZero, cv.NewCustomValidatorConfig())
-	return customValidator
+	customValidator := cv.NewCustomValidator("non-zero", nonZeroTagRegexp, ValidateNonZero, cv.NewCustomValidatorConfig(), []cv.Param{}...)
+	return customValidator.WithSchema(nonZeroSchema).WithDescription(nonZeroDescription)
 }
 
 // ValidateNonZero is a custom validation function for the non-zero custom validator
 func ValidateNonZero(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
-	value := f.Value
-	kind := value.Kind()
-
-	for kind == reflect.Interface || kind == reflect.Ptr || kind == reflect.UnsafePointer {
-		if f.Value.IsNil() {
-			return ZeroErrorf("non-zero field %v is nil", f.StructField.Name)
-		}
+	_, ok := cv.Deref(f.Value)
+	return ValidateNonZeroValue(ctx, !ok, ok && f.Value.IsZero(), vCtx.ForField(f))
+}
 
-		value = value.Elem()
-		kind = value.Kind()
+// ValidateNonZeroValue is a reflection free validation function for the non-zero custom validator.
+// isNil reports whether the field or any pointer it points to is nil, isZero whether the field itself has its zero value.
+func ValidateNonZeroValue(ctx context.Context, isNil, isZero bool, vCtx *cv.ValidationContext) error {
+	if isNil {
+		return ZeroErrorf("non-zero field %v is nil", vCtx.FieldName)
 	}
 
-	if f.Value.IsZero() {
-		return ZeroErrorf("non-zero field %v has zero value", f.StructField.Name)
+	if isZero {
+		return ZeroErrorf("non-zero field %v has zero value", vCtx.FieldName)
 	}
 	return nil
 }
@@ -0,0 +1,83 @@
+package dv
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// OneOfError is a custom error that will be returned by the oneof custom validator
+type OneOfError string
+
+// OneOfErrorf creates a new oneof error by providing a format string and optional parameters
+func OneOfErrorf(format string, a ...interface{}) OneOfError {
+	return OneOfError(fmt.Sprintf(format, a...))
+}
+
+// Error returns the error message string
+// Implements error interface
+func (err OneOfError) Error() string {
+	return string(err)
+}
+
+// oneOfParams are the parameters of the oneof custom validator
+var oneOfParams = []cv.Param{cv.Variadic(cv.StringParam("values"))}
+
+// OneOf creates a new oneof custom validator
+// Strings, numbers and booleans must be equal to one of the parameters e.g. oneof('New York','Los Angeles').
+func OneOf() *cv.CustomValidator {
+	oneOfTagString := `oneof\(.*\)`
+	oneOfTagRegex := regexp.MustCompile(oneOfTagString)
+
+	customValidator := cv.NewCustomValidator("oneof", oneOfTagRegex, ValidateOneOf, cv.NewCustomValidatorConfig().FailForNilValue().ForKinds(append([]reflect.Kind{reflect.String, reflect.Bool}, numberKinds...)...), oneOfParams...)
+	return customValidator.WithSchema(oneOfSchema).WithDescription(`be one of {{join .Values ", "}}`)
+}
+
+// ValidateOneOf is a custom validation function for the oneof custom validator
+func ValidateOneOf(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
+	value, ok := cv.Deref(f.Value)
+	if !ok {
+		return OneOfErrorf("oneof field %v is nil", f.StructField.Name)
+	}
+	kind := value.Kind()
+
+	var str string
+	switch kind {
+	case reflect.String:
+		str = value.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		str = strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		str = strconv.FormatUint(value.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		str = strconv.FormatFloat(value.Float(), 'f', -1, 64)
+	case reflect.Bool:
+		str = strconv.FormatBool(value.Bool())
+	default:
+		return OneOfErrorf("oneof field %v is of kind %v", f.StructField.Name, kind.String())
+	}
+
+	return ValidateOneOfString(ctx, str, vCtx.ForField(f))
+}
+
+// ValidateOneOfString is a reflection free validation function for the oneof custom validator receiving the formatted value of the field
+func ValidateOneOfString(ctx context.Context, str string, vCtx *cv.ValidationContext) error {
+	parsed, err := vCtx.ParseArgs(oneOfParams...)
+	if err != nil {
+		return OneOfErrorf("oneof field %v: %v", vCtx.FieldName, err)
+	}
+
+	values := make([]string, len(parsed.Args))
+	for i, arg := range parsed.Args {
+		if arg.String() == str {
+			return nil
+		}
+		values[i] = arg.String()
+	}
+
+	return OneOfErrorf("oneof field %v has value %q, but should be one of %q", vCtx.FieldName, str, values)
+}
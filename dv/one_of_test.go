@@ -0,0 +1,111 @@
+package dv
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+func TestValidateOneOf(t *testing.T) {
+	city := "New York"
+
+	values := []sizeTest{
+		{
+			name:   "string",
+			value:  reflect.ValueOf("Los Angeles"),
+			subTag: "oneof('New York','Los Angeles')",
+		},
+		{
+			name:   "pointer",
+			value:  reflect.ValueOf(&city),
+			subTag: "oneof('New York')",
+		},
+		{
+			name:   "literal with comma and bracket",
+			value:  reflect.ValueOf("a,b)"),
+			subTag: "oneof('a,b)',`c`)",
+		},
+		{
+			name:   "escaped quote",
+			value:  reflect.ValueOf("it's"),
+			subTag: `oneof('it\'s')`,
+		},
+		{
+			name:   "int",
+			value:  reflect.ValueOf(2),
+			subTag: "oneof(1,2,3)",
+		},
+		{
+			name:   "bool",
+			value:  reflect.ValueOf(true),
+			subTag: "oneof(true)",
+		},
+	}
+
+	for _, test := range values {
+		t.Run(test.name, func(t *testing.T) {
+			f := &cv.Field{
+				Value: test.value,
+			}
+
+			vCtx, err := OneOf().NewValidationContext(test.subTag)
+			assert.NoError(t, err)
+
+			err = ValidateOneOf(context.Background(), f, vCtx)
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateOneOf_fails(t *testing.T) {
+	values := []sizeTest{
+		{
+			name:   "string",
+			value:  reflect.ValueOf("Boston"),
+			subTag: "oneof('New York','Los Angeles')",
+		},
+		{
+			name:   "int",
+			value:  reflect.ValueOf(4),
+			subTag: "oneof(1,2,3)",
+		},
+		{
+			name:   "struct",
+			value:  reflect.ValueOf(struct{}{}),
+			subTag: "oneof(a)",
+		},
+	}
+
+	for _, test := range values {
+		t.Run(test.name, func(t *testing.T) {
+			f := &cv.Field{
+				Value: test.value,
+			}
+
+			vCtx, err := OneOf().NewValidationContext(test.subTag)
+			assert.NoError(t, err)
+
+			err = ValidateOneOf(context.Background(), f, vCtx)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestOneOf_failsWithoutArguments(t *testing.T) {
+	_, err := OneOf().NewValidationContext("oneof()")
+
+	assert.Error(t, err)
+}
+
+func TestValidateOneOfString_parsesSubTag(t *testing.T) {
+	assert.NoError(t, ValidateOneOfString(context.Background(), "b", &cv.ValidationContext{SubTag: "oneof(a,'b')"}))
+
+	err := ValidateOneOfString(context.Background(), "c", &cv.ValidationContext{SubTag: "oneof(a,'b')", FieldName: "Mode"})
+	assert.EqualError(t, err, `oneof field Mode has value "c", but should be one of ["a" "b"]`)
+}
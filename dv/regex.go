@@ -0,0 +1,60 @@
+package dv
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// RegexError is a custom error that will be returned by the regex custom validator
+type RegexError string
+
+// RegexErrorf creates a new regex error by providing a format string and optional parameters
+func RegexErrorf(format string, a ...interface{}) RegexError {
+	return RegexError(fmt.Sprintf(format, a...))
+}
+
+// Error returns the error message string
+// Implements error interface
+func (err RegexError) Error() string {
+	return string(err)
+}
+
+// regexParams are the parameters of the regex custom validator
+var regexParams = []cv.Param{cv.RegexParam("pattern")}
+
+// Regex creates a new regex custom validator
+// Strings must match the regular expression provided as string literal e.g. regex('^(foo|bar)+$').
+func Regex() *cv.CustomValidator {
+	regexTagString := `regex\(.*\)`
+	regexTagRegex := regexp.MustCompile(regexTagString)
+
+	customValidator := cv.NewTyped("regex", regexTagRegex, ValidateRegexString, cv.NewCustomValidatorConfig().FailForNilValue(), regexParams...)
+	return customValidator.WithSchema(regexSchema).WithDescription("match the pattern {{index .Values 0}}")
+}
+
+// ValidateRegex is a custom validation function for the regex custom validator
+func ValidateRegex(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
+	return cv.Typed("regex", ValidateRegexString)(ctx, f, vCtx)
+}
+
+// ValidateRegexString is a typed validation function for the regex custom validator
+func ValidateRegexString(ctx context.Context, str string, vCtx *cv.ValidationContext) error {
+	parsed, err := vCtx.ParseArgs(regexParams...)
+	if err != nil {
+		return RegexErrorf("regex field %v: %v", vCtx.FieldName, err)
+	}
+
+	pattern := parsed.Arg(0).Regexp()
+	if pattern == nil {
+		return RegexErrorf("regex field %v has no valid regular expression", vCtx.FieldName)
+	}
+
+	if !pattern.MatchString(str) {
+		return RegexErrorf("regex field %v does not match %v", vCtx.FieldName, pattern.String())
+	}
+
+	return nil
+}
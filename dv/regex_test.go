@@ -0,0 +1,96 @@
+package dv
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+func TestValidateRegex(t *testing.T) {
+	values := []sizeTest{
+		{
+			name:   "brackets and operators",
+			value:  reflect.ValueOf("foobar"),
+			subTag: "regex('^(foo||bar)+$')",
+		},
+		{
+			name:   "backslash",
+			value:  reflect.ValueOf("123"),
+			subTag: `regex('^\d+$')`,
+		},
+		{
+			name:   "backticks",
+			value:  reflect.ValueOf("a;b"),
+			subTag: "regex(`^a;b$`)",
+		},
+	}
+
+	for _, test := range values {
+		t.Run(test.name, func(t *testing.T) {
+			f := &cv.Field{
+				Value: test.value,
+			}
+
+			vCtx, err := Regex().NewValidationContext(test.subTag)
+			assert.NoError(t, err)
+
+			err = ValidateRegex(context.Background(), f, vCtx)
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateRegex_fails(t *testing.T) {
+	values := []sizeTest{
+		{
+			name:   "no match",
+			value:  reflect.ValueOf("foo1"),
+			subTag: "regex('^[a-z]+$')",
+		},
+		{
+			name:   "int",
+			value:  reflect.ValueOf(1),
+			subTag: "regex('1')",
+		},
+	}
+
+	for _, test := range values {
+		t.Run(test.name, func(t *testing.T) {
+			f := &cv.Field{
+				Value: test.value,
+			}
+
+			vCtx, err := Regex().NewValidationContext(test.subTag)
+			assert.NoError(t, err)
+
+			err = ValidateRegex(context.Background(), f, vCtx)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRegex_failsForInvalidRegularExpression(t *testing.T) {
+	_, err := Regex().NewValidationContext("regex('(')")
+
+	assert.Error(t, err)
+}
+
+func TestValidateRegex_parsesSubTag(t *testing.T) {
+	f := &cv.Field{
+		StructField: reflect.StructField{Name: "Code"},
+		Value:       reflect.ValueOf("abc"),
+	}
+
+	assert.NoError(t, ValidateRegex(context.Background(), f, &cv.ValidationContext{SubTag: "regex('^[a-z]+$')"}))
+
+	err := ValidateRegex(context.Background(), f, &cv.ValidationContext{SubTag: "regex('^[0-9]+$')"})
+	assert.EqualError(t, err, "regex field Code does not match ^[0-9]+$")
+
+	assert.Error(t, ValidateRegex(context.Background(), f, &cv.ValidationContext{SubTag: "regex('(')"}))
+}
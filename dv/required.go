@@ -12,17 +12,24 @@ func Required() *cv.CustomValidator {
 	requiredTagString := "required"
 	requiredTagRegexp := regexp.MustCompile(requiredTagString)
 
-	customValidator := cv.NewCustomValidator("required", requiredTagRegexp, ValidateRequired, cv.NewCustomValidatorConfig().FailForNilValue())
-	return customValidator
+	customValidator := cv.NewCustomValidator("required", requiredTagRegexp, ValidateRequired, cv.NewCustomValidatorConfig().FailForNilValue(), []cv.Param{}...)
+	return customValidator.WithSchema(nonZeroSchema).WithDescription(nonZeroDescription)
 }
 
 // ValidateRequired is a custom validation function for the required custom validator
 func ValidateRequired(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
-	err := ValidateNonNil(ctx, f, vCtx)
+	_, ok := cv.Deref(f.Value)
+	return ValidateRequiredValue(ctx, !ok, ok && f.Value.IsZero(), vCtx.ForField(f))
+}
+
+// ValidateRequiredValue is a reflection free validation function for the required custom validator.
+// isNil reports whether the field or any pointer it points to is nil, isZero whether the field itself has its zero value.
+func ValidateRequiredValue(ctx context.Context, isNil, isZero bool, vCtx *cv.ValidationContext) error {
+	err := ValidateNonNilValue(ctx, isNil, vCtx)
 	if err != nil {
 		return err
 	}
-	err = ValidateNonZero(ctx, f, vCtx)
+	err = ValidateNonZeroValue(ctx, isNil, isZero, vCtx)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,139 @@
+package dv
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// nonNilSchema requires the property to be present for kinds that can be nil.
+// Absent properties of all other kinds decode to their zero value, which is never nil.
+func nonNilSchema(kind reflect.Kind, vCtx *cv.ValidationContext) *cv.SchemaFragment {
+	switch kind {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return &cv.SchemaFragment{Required: true}
+	}
+
+	return &cv.SchemaFragment{}
+}
+
+// nonZeroSchema requires the property to be present and to differ from the zero value of its kind
+func nonZeroSchema(kind reflect.Kind, vCtx *cv.ValidationContext) *cv.SchemaFragment {
+	fragment := &cv.SchemaFragment{Required: true}
+
+	switch {
+	case kind == reflect.String:
+		fragment.Keywords = map[string]interface{}{"minLength": 1}
+	case kind == reflect.Bool:
+		fragment.Keywords = map[string]interface{}{"const": true}
+	case isNumberKind(kind):
+		fragment.Keywords = map[string]interface{}{"not": map[string]interface{}{"const": 0}}
+	}
+
+	return fragment
+}
+
+func emailSchema(kind reflect.Kind, vCtx *cv.ValidationContext) *cv.SchemaFragment {
+	return &cv.SchemaFragment{Keywords: map[string]interface{}{"format": "email"}}
+}
+
+func lenSchema(kind reflect.Kind, vCtx *cv.ValidationContext) *cv.SchemaFragment {
+	length := vCtx.Arg(0).Int()
+	return sizeSchema(kind, "min", float64(length), "max", float64(length))
+}
+
+func minSchema(kind reflect.Kind, vCtx *cv.ValidationContext) *cv.SchemaFragment {
+	if isNumberKind(kind) {
+		return &cv.SchemaFragment{Keywords: map[string]interface{}{"minimum": vCtx.Arg(0).Float()}}
+	}
+
+	return sizeSchema(kind, "min", math.Ceil(vCtx.Arg(0).Float()))
+}
+
+func maxSchema(kind reflect.Kind, vCtx *cv.ValidationContext) *cv.SchemaFragment {
+	if isNumberKind(kind) {
+		return &cv.SchemaFragment{Keywords: map[string]interface{}{"maximum": vCtx.Arg(0).Float()}}
+	}
+
+	return sizeSchema(kind, "max", math.Floor(vCtx.Arg(0).Float()))
+}
+
+// oneOfSchema creates an enum of the values converted to the JSON type of the kind
+func oneOfSchema(kind reflect.Kind, vCtx *cv.ValidationContext) *cv.SchemaFragment {
+	values := make([]interface{}, len(vCtx.Args))
+	for i, arg := range vCtx.Args {
+		var err error
+		switch {
+		case kind == reflect.String:
+			values[i] = arg.String()
+		case kind == reflect.Bool:
+			values[i], err = strconv.ParseBool(arg.String())
+		case isNumberKind(kind):
+			values[i], err = strconv.ParseFloat(arg.String(), 64)
+		default:
+			return nil
+		}
+
+		if err != nil {
+			// values of another kind never match
+			return nil
+		}
+	}
+
+	return &cv.SchemaFragment{Keywords: map[string]interface{}{"enum": values}}
+}
+
+func regexSchema(kind reflect.Kind, vCtx *cv.ValidationContext) *cv.SchemaFragment {
+	pattern := vCtx.Arg(0).Regexp()
+	if pattern == nil {
+		return nil
+	}
+
+	return &cv.SchemaFragment{Keywords: map[string]interface{}{"pattern": pattern.String()}}
+}
+
+// sizeSchema creates the keywords limiting the length of strings, arrays and objects.
+// The bounds are provided as pairs of min or max and the bound e.g. "min", 1, "max", 9.
+// The lengths of strings are counted in bytes by the validators but in characters by JSON Schema,
+// so only their maximum length is kept, which never rejects a string the validators accept.
+func sizeSchema(kind reflect.Kind, bounds ...interface{}) *cv.SchemaFragment {
+	var suffix string
+	switch kind {
+	case reflect.String:
+		suffix = "Length"
+	case reflect.Slice, reflect.Array:
+		suffix = "Items"
+	case reflect.Map:
+		suffix = "Properties"
+	default:
+		return nil
+	}
+
+	keywords := map[string]interface{}{}
+	for i := 0; i+1 < len(bounds); i += 2 {
+		if kind == reflect.String && bounds[i] == "min" {
+			continue
+		}
+
+		size := int(math.Max(bounds[i+1].(float64), 0))
+		keywords[bounds[i].(string)+suffix] = size
+	}
+
+	if len(keywords) == 0 {
+		return nil
+	}
+
+	return &cv.SchemaFragment{Keywords: keywords}
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	for _, numberKind := range numberKinds {
+		if kind == numberKind {
+			return true
+		}
+	}
+
+	return false
+}
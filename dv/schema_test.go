@@ -0,0 +1,48 @@
+package dv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+func TestSchema(t *testing.T) {
+	tests := []struct {
+		name            string
+		customValidator *cv.CustomValidator
+		subTag          string
+		kind            reflect.Kind
+		expected        *cv.SchemaFragment
+	}{
+		{"non-nil", NonNil(), "non-nil", reflect.Ptr, &cv.SchemaFragment{Required: true}},
+		{"non-nil map", NonNil(), "non-nil", reflect.Map, &cv.SchemaFragment{Required: true}},
+		// values of other kinds are never nil, so absent properties are valid
+		{"non-nil string", NonNil(), "non-nil", reflect.String, &cv.SchemaFragment{}},
+		{"non-nil int", NonNil(), "non-nil", reflect.Int, &cv.SchemaFragment{}},
+		{"required string", Required(), "required", reflect.String, &cv.SchemaFragment{Keywords: map[string]interface{}{"minLength": 1}, Required: true}},
+		{"non-zero int", NonZero(), "non-zero", reflect.Int, &cv.SchemaFragment{Keywords: map[string]interface{}{"not": map[string]interface{}{"const": 0}}, Required: true}},
+		{"email", Email(), "email", reflect.String, &cv.SchemaFragment{Keywords: map[string]interface{}{"format": "email"}}},
+		{"len slice", Len(), "len(3)", reflect.Slice, &cv.SchemaFragment{Keywords: map[string]interface{}{"minItems": 3, "maxItems": 3}}},
+		// lengths of strings are counted in bytes, so only the maximum length is kept
+		{"len string", Len(), "len(3)", reflect.String, &cv.SchemaFragment{Keywords: map[string]interface{}{"maxLength": 3}}},
+		{"len int", Len(), "len(3)", reflect.Int, nil},
+		{"min float", Min(), "min(1.5)", reflect.Float64, &cv.SchemaFragment{Keywords: map[string]interface{}{"minimum": 1.5}}},
+		{"min string", Min(), "min(1.5)", reflect.String, nil},
+		{"max map", Max(), "max(2.5)", reflect.Map, &cv.SchemaFragment{Keywords: map[string]interface{}{"maxProperties": 2}}},
+		{"oneof int", OneOf(), "oneof(1,2)", reflect.Int, &cv.SchemaFragment{Keywords: map[string]interface{}{"enum": []interface{}{1.0, 2.0}}}},
+		{"oneof invalid bool", OneOf(), "oneof(yes)", reflect.Bool, nil},
+		{"regex", Regex(), "regex(^a$)", reflect.String, &cv.SchemaFragment{Keywords: map[string]interface{}{"pattern": "^a$"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validationCtx, err := test.customValidator.NewValidationContext(test.subTag)
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expected, test.customValidator.Schema(test.kind, validationCtx))
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 
 	"github.com/gogo-gadget/validator"
@@ -16,15 +17,20 @@ func main() {
 }
 
 func exampleValidator() *cv.CustomValidator {
-	exampleString := "example"
+	exampleString := `example\(.*\)`
 	exampleRegexp := regexp.MustCompile(exampleString)
 
-	customValidator := cv.NewCustomValidator("example", exampleRegexp, validateExampleValidator, cv.NewCustomValidatorConfig().FailForNilValue())
+	customValidator := cv.NewCustomValidator("example", exampleRegexp, validateExampleValidator, cv.NewCustomValidatorConfig().FailForNilValue(), cv.IntParam("number"))
 	return customValidator
 }
 
 func validateExampleValidator(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
 	// Validation of the field is placed here
+	// The arguments of the sub tag e.g. example(3) are already parsed according to the parameters
+	number := vCtx.Arg(0).Int()
+	if number < 0 {
+		return fmt.Errorf("field %v must not use a negative number", f.StructField.Name)
+	}
 	// ...
 	return nil
 }
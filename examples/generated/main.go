@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"log"
+)
+
+//go:generate go run ../../cmd/validator-gen -type User
+
+// Address is validated by the generated Validate method of User
+type Address struct {
+	Street string  `validator:"required"`
+	City   string  `validator:"oneof('New York','Los Angeles')"`
+	Zip    *string `validator:"len(5)"`
+}
+
+// User has a generated Validate method which does not use reflection
+type User struct {
+	Name     string         `validator:"required && len(9)"`
+	Email    string         `validator:"email || len(0)"`
+	Age      int            `validator:"min(18) && max(130)"`
+	Nickname *string        `validator:"if(non-nil)then(min(3))"`
+	Website  string         `validator:"!len(0) && regex('^https://')"`
+	Tags     []string       `validator:"dive(required && max(10))"`
+	Roles    map[string]int `validator:"dive(oneof(1,2,3))"`
+	Address  *Address
+}
+
+func main() {
+	zip := "10001"
+	user := &User{
+		Name:    "top-level",
+		Email:   "gopher@example.com",
+		Age:     42,
+		Website: "https://go.dev",
+		Tags:    []string{"gopher"},
+		Roles:   map[string]int{"admin": 1},
+		Address: &Address{
+			Street: "5th Avenue",
+			City:   "New York",
+			Zip:    &zip,
+		},
+	}
+
+	err := user.Validate(context.Background())
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	log.Println("hurray, validation succeeded")
+}
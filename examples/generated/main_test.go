@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator"
+)
+
+func validUser() *User {
+	zip := "10001"
+	return &User{
+		Name:    "top-level",
+		Email:   "gopher@example.com",
+		Age:     42,
+		Website: "https://go.dev",
+		Tags:    []string{"gopher"},
+		Roles:   map[string]int{"admin": 1},
+		Address: &Address{
+			Street: "5th Avenue",
+			City:   "New York",
+			Zip:    &zip,
+		},
+	}
+}
+
+func TestUser_Validate_matchesReflectiveValidator(t *testing.T) {
+	short := "go"
+	tests := []struct {
+		name   string
+		modify func(user *User)
+	}{
+		{name: "valid", modify: func(user *User) {}},
+		{name: "empty name", modify: func(user *User) { user.Name = "" }},
+		{name: "name of wrong length", modify: func(user *User) { user.Name = "gopher" }},
+		{name: "empty email", modify: func(user *User) { user.Email = "" }},
+		{name: "invalid email", modify: func(user *User) { user.Email = "gopher" }},
+		{name: "too young", modify: func(user *User) { user.Age = 17 }},
+		{name: "too old", modify: func(user *User) { user.Age = 131 }},
+		{name: "short nickname", modify: func(user *User) { user.Nickname = &short }},
+		{name: "empty website", modify: func(user *User) { user.Website = "" }},
+		{name: "insecure website", modify: func(user *User) { user.Website = "http://go.dev" }},
+		{name: "empty tag", modify: func(user *User) { user.Tags = []string{"gopher", ""} }},
+		{name: "long tag", modify: func(user *User) { user.Tags = []string{"gopher", "gopher-gopher"} }},
+		{name: "invalid role", modify: func(user *User) { user.Roles = map[string]int{"admin": 4} }},
+		{name: "nil address", modify: func(user *User) { user.Address = nil }},
+		{name: "empty street", modify: func(user *User) { user.Address.Street = "" }},
+		{name: "invalid city", modify: func(user *User) { user.Address.City = "Berlin" }},
+		{name: "nil zip", modify: func(user *User) { user.Address.Zip = nil }},
+		{name: "zip of wrong length", modify: func(user *User) { user.Address.Zip = &short }},
+	}
+
+	v := validator.NewValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := validUser()
+			tt.modify(user)
+
+			reflective := v.Validate(context.Background(), user)
+			generated := user.Validate(context.Background())
+
+			if reflective == nil {
+				assert.NoError(t, generated)
+				return
+			}
+			assert.EqualError(t, generated, reflective.Error())
+		})
+	}
+}
+
+func TestUser_Validate_nilUser(t *testing.T) {
+	var user *User
+
+	reflective := validator.NewValidator().Validate(context.Background(), user)
+	generated := user.Validate(context.Background())
+
+	assert.EqualError(t, generated, reflective.Error())
+}
@@ -0,0 +1,219 @@
+// Code generated by validator-gen; DO NOT EDIT.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/gogo-gadget/validator"
+	"github.com/gogo-gadget/validator/dv"
+)
+
+var (
+	validatorGenCtx0  = validator.MustValidationContext(dv.Required(), "required", "Name")
+	validatorGenCtx1  = validator.MustValidationContext(dv.Len(), "len(9)", "Name")
+	validatorGenCtx2  = validator.MustValidationContext(dv.Email(), "email", "Email")
+	validatorGenCtx3  = validator.MustValidationContext(dv.Len(), "len(0)", "Email")
+	validatorGenCtx4  = validator.MustValidationContext(dv.Min(), "min(18)", "Age")
+	validatorGenCtx5  = validator.MustValidationContext(dv.Max(), "max(130)", "Age")
+	validatorGenCtx6  = validator.MustValidationContext(dv.NonNil(), "non-nil", "Nickname")
+	validatorGenCtx7  = validator.MustValidationContext(dv.Min(), "min(3)", "Nickname")
+	validatorGenCtx8  = validator.MustValidationContext(dv.Len(), "len(0)", "Website")
+	validatorGenCtx9  = validator.MustValidationContext(dv.Regex(), "regex('^https://')", "Website")
+	validatorGenCtx10 = validator.MustValidationContext(dv.Required(), "required", "Tags")
+	validatorGenCtx11 = validator.MustValidationContext(dv.Max(), "max(10)", "Tags")
+	validatorGenCtx12 = validator.MustValidationContext(dv.OneOf(), "oneof(1,2,3)", "Roles")
+	validatorGenCtx13 = validator.MustValidationContext(dv.Required(), "required", "Street")
+	validatorGenCtx14 = validator.MustValidationContext(dv.OneOf(), "oneof('New York','Los Angeles')", "City")
+	validatorGenCtx15 = validator.MustValidationContext(dv.Len(), "len(5)", "Zip")
+)
+
+func init() {
+	validator.MustCheckGeneratedTags(User{}, map[string]string{
+		"Name":     "required && len(9)",
+		"Email":    "email || len(0)",
+		"Age":      "min(18) && max(130)",
+		"Nickname": "if(non-nil)then(min(3))",
+		"Website":  "!len(0) && regex('^https://')",
+		"Tags":     "dive(required && max(10))",
+		"Roles":    "dive(oneof(1,2,3))",
+		"Address":  "",
+	})
+	validator.MustCheckGeneratedTags(Address{}, map[string]string{
+		"Street": "required",
+		"City":   "oneof('New York','Los Angeles')",
+		"Zip":    "len(5)",
+	})
+}
+
+// Validate validates User by its validator tags without reflection.
+func (s *User) Validate(ctx context.Context) error {
+	return s.validatorGenValidate(ctx, "")
+}
+
+func (s *User) validatorGenValidate(ctx context.Context, prefix string) error {
+	if s == nil {
+		return errors.New("validation failed since validator for regex: required failed on nil value for Field: " + prefix + "Name")
+	}
+
+	// Name `validator:"required && len(9)"`
+	var err1 error
+	err1 = dv.ValidateRequiredValue(ctx, false, s.Name == "", validatorGenCtx0)
+	var err2 error
+	err2 = dv.ValidateLenValue(ctx, len(s.Name), validatorGenCtx1)
+	var err3 error
+	if err1 != nil || err2 != nil {
+		err3 = errors.New("&& validation of required and len(9) of Field " + prefix + "Name" + " failed")
+	}
+	if err3 != nil {
+		return err3
+	}
+
+	// Email `validator:"email || len(0)"`
+	var err4 error
+	err4 = dv.ValidateEmailString(ctx, s.Email, validatorGenCtx2)
+	var err5 error
+	err5 = dv.ValidateLenValue(ctx, len(s.Email), validatorGenCtx3)
+	var err6 error
+	if err4 != nil && err5 != nil {
+		err6 = errors.New("|| validation of email or len(0) of Field " + prefix + "Email" + " failed")
+	}
+	if err6 != nil {
+		return err6
+	}
+
+	// Age `validator:"min(18) && max(130)"`
+	var err7 error
+	err7 = dv.ValidateMinValue(ctx, float64(s.Age), validatorGenCtx4)
+	var err8 error
+	err8 = dv.ValidateMaxValue(ctx, float64(s.Age), validatorGenCtx5)
+	var err9 error
+	if err7 != nil || err8 != nil {
+		err9 = errors.New("&& validation of min(18) and max(130) of Field " + prefix + "Age" + " failed")
+	}
+	if err9 != nil {
+		return err9
+	}
+
+	// Nickname `validator:"if(non-nil)then(min(3))"`
+	var err10 error
+	err10 = dv.ValidateNonNilValue(ctx, s.Nickname == nil, validatorGenCtx6)
+	var err11 error
+	if err10 == nil {
+		var err12 error
+		if s.Nickname == nil {
+			err12 = dv.MinErrorf("min field %v is nil", "Nickname")
+		} else {
+			err12 = dv.ValidateMinValue(ctx, float64(len(*s.Nickname)), validatorGenCtx7)
+		}
+		err11 = err12
+	}
+	if err11 != nil {
+		return err11
+	}
+
+	// Website `validator:"!len(0) && regex('^https://')"`
+	var err13 error
+	err13 = dv.ValidateLenValue(ctx, len(s.Website), validatorGenCtx8)
+	var err14 error
+	if err13 == nil {
+		err14 = errors.New("validation of !len(0) of Field " + prefix + "Website" + " failed")
+	}
+	var err15 error
+	err15 = dv.ValidateRegexString(ctx, s.Website, validatorGenCtx9)
+	var err16 error
+	if err14 != nil || err15 != nil {
+		err16 = errors.New("&& validation of !len(0) and regex('^https://') of Field " + prefix + "Website" + " failed")
+	}
+	if err16 != nil {
+		return err16
+	}
+
+	// Tags `validator:"dive(required && max(10))"`
+	var err17 error
+	for key18, elem19 := range s.Tags {
+		name20 := "Tags" + "[" + strconv.Itoa(key18) + "]"
+		var err21 error
+		vCtx22 := *validatorGenCtx10
+		vCtx22.FieldName = name20
+		err21 = dv.ValidateRequiredValue(ctx, false, elem19 == "", &vCtx22)
+		var err23 error
+		vCtx24 := *validatorGenCtx11
+		vCtx24.FieldName = name20
+		err23 = dv.ValidateMaxValue(ctx, float64(len(elem19)), &vCtx24)
+		var err25 error
+		if err21 != nil || err23 != nil {
+			err25 = errors.New("&& validation of required and max(10) of Field " + prefix + name20 + " failed")
+		}
+		if err25 != nil {
+			err17 = err25
+			break
+		}
+	}
+	if err17 != nil {
+		return err17
+	}
+
+	// Roles `validator:"dive(oneof(1,2,3))"`
+	var err26 error
+	for key27, elem28 := range s.Roles {
+		name29 := "Roles" + "[" + key27 + "]"
+		var err30 error
+		vCtx31 := *validatorGenCtx12
+		vCtx31.FieldName = name29
+		err30 = dv.ValidateOneOfString(ctx, strconv.FormatInt(int64(elem28), 10), &vCtx31)
+		if err30 != nil {
+			err26 = err30
+			break
+		}
+	}
+	if err26 != nil {
+		return err26
+	}
+
+	if err := s.Address.validatorGenValidate(ctx, prefix+"Address."); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Validate validates Address by its validator tags without reflection.
+func (s *Address) Validate(ctx context.Context) error {
+	return s.validatorGenValidate(ctx, "")
+}
+
+func (s *Address) validatorGenValidate(ctx context.Context, prefix string) error {
+	if s == nil {
+		return errors.New("validation failed since validator for regex: required failed on nil value for Field: " + prefix + "Street")
+	}
+
+	// Street `validator:"required"`
+	var err32 error
+	err32 = dv.ValidateRequiredValue(ctx, false, s.Street == "", validatorGenCtx13)
+	if err32 != nil {
+		return err32
+	}
+
+	// City `validator:"oneof('New York','Los Angeles')"`
+	var err33 error
+	err33 = dv.ValidateOneOfString(ctx, s.City, validatorGenCtx14)
+	if err33 != nil {
+		return err33
+	}
+
+	// Zip `validator:"len(5)"`
+	var err34 error
+	if s.Zip == nil {
+		err34 = dv.LenErrorf("len field %v is nil", "Zip")
+	} else {
+		err34 = dv.ValidateLenValue(ctx, len(*s.Zip), validatorGenCtx15)
+	}
+	if err34 != nil {
+		return err34
+	}
+
+	return nil
+}
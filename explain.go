@@ -0,0 +1,259 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldExplanation is the natural-language description of the validation rules of a field
+type FieldExplanation struct {
+	// Path is the full name of the field as used in validation errors e.g. Address.Street
+	Path string `json:"path"`
+	// Text is the requirement of the field e.g. "must have exactly 9 characters"
+	Text string `json:"text"`
+}
+
+// Sentence returns the requirement as sentence with the path of the field as subject e.g. "Name must have exactly 9 characters."
+func (explanation *FieldExplanation) Sentence() string {
+	return fmt.Sprintf("%v %v.", explanation.Path, explanation.Text)
+}
+
+// Explain describes the validation rules of the fields of the type of the provided value and its nested structs
+// in natural language by the description templates of the custom validators, e.g. "must be a valid e-mail address or have exactly 0 characters".
+// Sub tags of custom validators without description are described by "satisfy" and the sub tag.
+// Fields without validation rules are omitted.
+// Usage:
+//
+//	explanations, err := validator.Explain(User{})
+//	for _, explanation := range explanations {
+//		fmt.Println(explanation.Sentence())
+//	}
+func (v *Validator) Explain(i interface{}) ([]*FieldExplanation, error) {
+	rType := reflect.TypeOf(i)
+	if rType == nil {
+		return nil, fmt.Errorf("explanation of a nil value is not supported")
+	}
+
+	rType = getUnderlyingType(rType)
+	if rType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("explanation of kind %v is not supported", rType.Kind())
+	}
+
+	return v.explainStruct(rType, "", map[reflect.Type]bool{rType: true})
+}
+
+// explainStruct explains the fields of a struct type while parents contains the struct types of all parent fields
+func (v *Validator) explainStruct(structType reflect.Type, path string, parents map[reflect.Type]bool) ([]*FieldExplanation, error) {
+	var explanations []*FieldExplanation
+
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		fieldPath := path + structField.Name
+
+		node, err := v.compileField(structType, structField)
+		if err != nil {
+			return nil, withPath(err, fieldPath)
+		}
+
+		if node != nil {
+			phrase, err := explainNode(node, structField.Type, baseForm)
+			if err != nil {
+				return nil, withPath(err, fieldPath)
+			}
+
+			explanations = append(explanations, &FieldExplanation{
+				Path: fieldPath,
+				Text: "must " + phrase,
+			})
+		}
+
+		fieldType := getUnderlyingType(structField.Type)
+		if fieldType.Kind() != reflect.Struct || parents[fieldType] {
+			continue
+		}
+
+		parents[fieldType] = true
+		nested, err := v.explainStruct(fieldType, fieldPath+".", parents)
+		delete(parents, fieldType)
+		if err != nil {
+			return nil, err
+		}
+
+		explanations = append(explanations, nested...)
+	}
+
+	return explanations, nil
+}
+
+// verbForm defines how the verbs of a description are conjugated
+type verbForm int
+
+const (
+	// baseForm completes sentences like "The field must ..." e.g. "be set and have at most 9 characters"
+	baseForm verbForm = iota
+	// singularForm completes sentences like "if it ..." e.g. "is set and has at most 9 characters"
+	singularForm
+	// pluralForm completes sentences like "elements that ..." e.g. "are set and have at most 9 characters"
+	pluralForm
+)
+
+// explainNode describes an expression tree as verb phrase of the provided form
+func explainNode(node *Node, fieldType reflect.Type, form verbForm) (string, error) {
+	switch node.Kind {
+	case AndNode, OrNode:
+		left, err := explainNode(node.Children[0], fieldType, form)
+		if err != nil {
+			return "", err
+		}
+
+		right, err := explainNode(node.Children[1], fieldType, form)
+		if err != nil {
+			return "", err
+		}
+
+		if node.Kind == AndNode {
+			return fmt.Sprintf("%v and %v", left, right), nil
+		}
+		return fmt.Sprintf("%v or %v", left, right), nil
+	case NotNode:
+		operand, err := explainNode(node.Children[0], fieldType, baseForm)
+		if err != nil {
+			return "", err
+		}
+
+		return conjugate(negate(operand, node.Children[0].Kind == ValidationNode), form), nil
+	case IfNode:
+		return explainIf(node, fieldType, form)
+	case DiveNode:
+		return explainDive(node, fieldType, form)
+	case AliasNode:
+		return explainNode(node.Children[0], fieldType, form)
+	}
+
+	return explainValidation(node, fieldType, form)
+}
+
+func explainValidation(node *Node, fieldType reflect.Type, form verbForm) (string, error) {
+	kind := getUnderlyingType(fieldType).Kind()
+
+	var phrases []string
+	for _, b := range node.bindings {
+		phrase, ok, err := b.customValidator.Explain(kind, b.validationCtx)
+		if err != nil {
+			return "", fmt.Errorf("description of validation %v failed: %w", node.SubTag, err)
+		}
+
+		if ok {
+			phrases = append(phrases, phrase)
+		}
+	}
+
+	if len(phrases) == 0 {
+		phrases = append(phrases, fmt.Sprintf("satisfy %v", node.SubTag))
+	}
+
+	for i, phrase := range phrases {
+		phrases[i] = conjugate(phrase, form)
+	}
+
+	return strings.Join(phrases, " and "), nil
+}
+
+// explainIf describes a conditional expression e.g. "have at least 3 characters if it is set, otherwise be empty"
+func explainIf(node *Node, fieldType reflect.Type, form verbForm) (string, error) {
+	condition, err := explainNode(node.Children[0], fieldType, singularForm)
+	if err != nil {
+		return "", err
+	}
+
+	then, err := explainNode(node.Children[1], fieldType, form)
+	if err != nil {
+		return "", err
+	}
+
+	phrase := fmt.Sprintf("%v if it %v", then, condition)
+	if len(node.Children) > 2 {
+		otherwise, err := explainNode(node.Children[2], fieldType, form)
+		if err != nil {
+			return "", err
+		}
+
+		phrase = fmt.Sprintf("%v, otherwise %v", phrase, otherwise)
+	}
+
+	return phrase, nil
+}
+
+// explainDive describes a dive expression e.g. "contain only elements that are set"
+func explainDive(node *Node, fieldType reflect.Type, form verbForm) (string, error) {
+	fieldType = getUnderlyingType(fieldType)
+
+	var elemType reflect.Type
+	switch fieldType.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		elemType = fieldType.Elem()
+	default:
+		return "", fmt.Errorf("dive validation of kind %v is not supported", fieldType.Kind())
+	}
+
+	elem, err := explainNode(node.Children[0], elemType, pluralForm)
+	if err != nil {
+		return "", err
+	}
+
+	if fieldType.Kind() == reflect.Map {
+		return conjugate(fmt.Sprintf("contain only values that %v", elem), form), nil
+	}
+	return conjugate(fmt.Sprintf("contain only elements that %v", elem), form), nil
+}
+
+// negate negates a verb phrase in base form e.g. "be set" to "not be set" or "not be empty" to "be empty".
+// Phrases of multiple validations are negated as a whole.
+func negate(phrase string, single bool) string {
+	if single && strings.HasPrefix(phrase, "not ") {
+		return strings.TrimPrefix(phrase, "not ")
+	}
+
+	if single {
+		return "not " + phrase
+	}
+
+	return fmt.Sprintf("not (%v)", phrase)
+}
+
+// conjugate conjugates the first verb of a phrase in base form e.g. "have 9 characters" to "has 9 characters" in singular form
+func conjugate(phrase string, form verbForm) string {
+	if form == baseForm {
+		return phrase
+	}
+
+	if strings.HasPrefix(phrase, "not be ") {
+		return conjugate("be", form) + " not " + strings.TrimPrefix(phrase, "not be ")
+	}
+
+	if strings.HasPrefix(phrase, "not ") {
+		return conjugate("do", form) + " " + phrase
+	}
+
+	verb, rest := phrase, ""
+	if i := strings.Index(phrase, " "); i >= 0 {
+		verb, rest = phrase[:i], phrase[i:]
+	}
+
+	switch {
+	case verb == "be" && form == pluralForm:
+		verb = "are"
+	case form == pluralForm:
+	case verb == "be":
+		verb = "is"
+	case verb == "have":
+		verb = "has"
+	case strings.HasSuffix(verb, "s"), strings.HasSuffix(verb, "sh"), strings.HasSuffix(verb, "ch"), strings.HasSuffix(verb, "x"), strings.HasSuffix(verb, "o"):
+		verb += "es"
+	default:
+		verb += "s"
+	}
+
+	return verb + rest
+}
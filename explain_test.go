@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+type ExplainUser struct {
+	ID       string            `validator:"len(9)"`
+	Email    string            `validator:"email || len(0)"`
+	Age      int               `validator:"required && min(18) && max(130)"`
+	Role     string            `validator:"oneof(admin,user)"`
+	Nickname *string           `validator:"if(non-nil) then(min(3)) elif(len(0)) then(!non-zero) else(regex(^[a-z]+$))"`
+	Tags     []string          `validator:"!len(0) && dive(non-zero)"`
+	Labels   map[string]string `validator:"dive(!regex(^x))"`
+	Admin    bool              `validator:"non-zero"`
+	Code     string            `validator:"lowercase"`
+	Address  *ExplainAddress
+	Ignored  string
+}
+
+type ExplainAddress struct {
+	City   string `validator:"!(len(0) || len(1))"`
+	Parent *ExplainAddress
+}
+
+func TestValidator_Explain(t *testing.T) {
+	validator := NewValidator()
+	validator.RegisterCustomValidator(cv.NewCustomValidator("lowercase", regexp.MustCompile("^lowercase$"), func(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
+		return nil
+	}, cv.NewCustomValidatorConfig()))
+
+	explanations, err := validator.Explain(&ExplainUser{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	sentences := make([]string, len(explanations))
+	for i, explanation := range explanations {
+		sentences[i] = explanation.Sentence()
+	}
+
+	assert.Equal(t, []string{
+		"ID must have exactly 9 characters.",
+		"Email must be a valid e-mail address or have exactly 0 characters.",
+		"Age must not be zero and be at least 18 and be at most 130.",
+		"Role must be one of admin, user.",
+		"Nickname must have at least 3 characters if it is set, otherwise be empty if it has exactly 0 characters, otherwise match the pattern ^[a-z]+$.",
+		"Tags must not have exactly 0 elements and contain only elements that are not empty.",
+		"Labels must contain only values that do not match the pattern ^x.",
+		"Admin must be true.",
+		"Code must satisfy lowercase.",
+		"Address.City must not (have exactly 0 characters or have exactly 1 characters).",
+	}, sentences)
+}
+
+func TestValidator_Explain_fails(t *testing.T) {
+	validator := NewValidator()
+
+	_, err := validator.Explain(nil)
+	assert.EqualError(t, err, "explanation of a nil value is not supported")
+
+	_, err = validator.Explain(3)
+	assert.EqualError(t, err, "explanation of kind int is not supported")
+
+	type Invalid struct {
+		Age int `validator:"dive(min(1))"`
+	}
+	_, err = validator.Explain(Invalid{})
+	assert.EqualError(t, err, "field Age: dive validation of kind int is not supported")
+}
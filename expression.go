@@ -0,0 +1,350 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// NodeKind defines the kind of a node of a parsed validator tag expression
+type NodeKind string
+
+const (
+	// ValidationNode is a leaf of the expression tree and contains a single sub tag e.g. len(9)
+	ValidationNode NodeKind = "validation"
+	// AndNode succeeds if both of its children succeed
+	AndNode NodeKind = "and"
+	// OrNode succeeds if at least one of its children succeeds
+	OrNode NodeKind = "or"
+	// NotNode succeeds if its only child fails
+	NotNode NodeKind = "not"
+	// IfNode contains a condition, a then statement and optionally an else statement as children.
+	// elif statements are represented by nested if nodes in the else statement.
+	IfNode NodeKind = "if"
+	// DiveNode validates its only child on every element of a slice, array or map
+	DiveNode NodeKind = "dive"
+	// AliasNode contains the expanded expression of an alias or macro as only child
+	AliasNode NodeKind = "alias"
+)
+
+// Node is a node of the expression tree of a parsed validator tag
+type Node struct {
+	Kind NodeKind
+	// SubTag is the part of the tag the node was created from e.g. len(9) or email&&len(13)
+	SubTag   string
+	Children []*Node
+	// Message optionally replaces the message of the error of the node e.g. username.length for len(9)#msg('username.length').
+	// It is used as message key if the error is translated.
+	Message string
+
+	// bindings contains the custom validators matching the sub tag of a validation node
+	bindings []binding
+}
+
+// NewValidationNode creates a new validation node for a single sub tag e.g. len(9)
+func NewValidationNode(subTag string) *Node {
+	return &Node{
+		Kind:   ValidationNode,
+		SubTag: subTag,
+	}
+}
+
+// NewAndNode creates a new node which succeeds if both provided nodes succeed
+func NewAndNode(left, right *Node) *Node {
+	return &Node{
+		Kind:     AndNode,
+		SubTag:   fmt.Sprintf("%v&&%v", operandSubTag(left), right.SubTag),
+		Children: []*Node{left, right},
+	}
+}
+
+// NewOrNode creates a new node which succeeds if at least one of the provided nodes succeeds
+func NewOrNode(left, right *Node) *Node {
+	return &Node{
+		Kind:     OrNode,
+		SubTag:   fmt.Sprintf("%v||%v", operandSubTag(left), right.SubTag),
+		Children: []*Node{left, right},
+	}
+}
+
+// NewNotNode creates a new node which succeeds if the provided node fails
+func NewNotNode(operand *Node) *Node {
+	return &Node{
+		Kind:     NotNode,
+		SubTag:   fmt.Sprintf("!%v", operandSubTag(operand)),
+		Children: []*Node{operand},
+	}
+}
+
+// NewIfNode creates a new node which validates the then node if the condition succeeds and the otherwise node if not.
+// The otherwise node is optional and can be nil. It represents an elif statement if it is an if node itself.
+func NewIfNode(condition, then, otherwise *Node) *Node {
+	node := &Node{
+		Kind:     IfNode,
+		SubTag:   fmt.Sprintf("if(%v)then(%v)", condition.SubTag, then.SubTag),
+		Children: []*Node{condition, then},
+	}
+
+	if otherwise != nil {
+		if otherwise.Kind == IfNode {
+			node.SubTag = fmt.Sprintf("%vel%v", node.SubTag, otherwise.SubTag)
+		} else {
+			node.SubTag = fmt.Sprintf("%velse(%v)", node.SubTag, otherwise.SubTag)
+		}
+		node.Children = append(node.Children, otherwise)
+	}
+
+	return node
+}
+
+// NewDiveNode creates a new node which validates the provided node on every element of a slice, array or map
+func NewDiveNode(elem *Node) *Node {
+	return &Node{
+		Kind:     DiveNode,
+		SubTag:   fmt.Sprintf("dive(%v)", elem.SubTag),
+		Children: []*Node{elem},
+	}
+}
+
+// operandSubTag returns the sub tag of a node wrapped in brackets if it is a logical operation,
+// since logical operators are right associative and share the same precedence.
+func operandSubTag(node *Node) string {
+	if node.Kind == AndNode || node.Kind == OrNode {
+		return fmt.Sprintf("(%v)", node.SubTag)
+	}
+
+	return node.SubTag
+}
+
+// clone creates a deep copy of the expression tree
+func (node *Node) clone() *Node {
+	if node == nil {
+		return nil
+	}
+
+	clone := *node
+	clone.Children = make([]*Node, len(node.Children))
+	for i, child := range node.Children {
+		clone.Children[i] = child.clone()
+	}
+
+	return &clone
+}
+
+// expression returns a string identifying the expression tree including the messages of its nodes,
+// which in contrast to the sub tag of the root node distinguishes e.g. the messages of nested nodes
+func (node *Node) expression() string {
+	var sb strings.Builder
+	node.writeExpression(&sb)
+
+	return sb.String()
+}
+
+func (node *Node) writeExpression(sb *strings.Builder) {
+	if node == nil {
+		sb.WriteString("nil")
+		return
+	}
+
+	sb.WriteString(string(node.Kind))
+	sb.WriteByte('(')
+	sb.WriteString(strconv.Quote(node.SubTag))
+	for _, child := range node.Children {
+		sb.WriteByte(',')
+		child.writeExpression(sb)
+	}
+	sb.WriteByte(')')
+
+	if node.Message != "" {
+		sb.WriteByte('#')
+		sb.WriteString(strconv.Quote(node.Message))
+	}
+}
+
+// Validations returns all validation nodes of the expression tree
+func (node *Node) Validations() []*Node {
+	if node == nil {
+		return nil
+	}
+
+	if node.Kind == ValidationNode {
+		return []*Node{node}
+	}
+
+	var nodes []*Node
+	for _, child := range node.Children {
+		nodes = append(nodes, child.Validations()...)
+	}
+
+	return nodes
+}
+
+// TODO add multiple errors to return
+func (v *Validator) evaluate(ctx context.Context, field *cv.Field, node *Node) error {
+	if traced, err := v.traceNode(ctx, field, node); traced {
+		return err
+	}
+
+	err := v.evaluateNode(ctx, field, node)
+	if err != nil && node.Message != "" {
+		return withMessage(err, field, node)
+	}
+
+	return err
+}
+
+func (v *Validator) evaluateNode(ctx context.Context, field *cv.Field, node *Node) error {
+	switch node.Kind {
+	case AndNode:
+		return v.evaluateAnd(ctx, field, node)
+	case OrNode:
+		return v.evaluateOr(ctx, field, node)
+	case NotNode:
+		return v.evaluateNot(ctx, field, node)
+	case IfNode:
+		return v.evaluateIf(ctx, field, node)
+	case DiveNode:
+		return v.evaluateDive(ctx, field, node)
+	case AliasNode:
+		return v.evaluateAlias(ctx, field, node)
+	}
+
+	return v.evaluateValidation(ctx, field, node)
+}
+
+func (v *Validator) evaluateValidation(ctx context.Context, field *cv.Field, node *Node) error {
+	for _, b := range node.bindings {
+		if v.collectingRemote && v.remoteValidators[b.customValidator.ID] == nil {
+			continue
+		}
+
+		validationCtx := resolveValidationContext(field, b.validationCtx)
+		err := v.callValidator(ctx, b.customValidator, field, validationCtx)
+		if err != nil {
+			return newFieldError(field, b.customValidator.ID, node, validationCtx, err, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (v *Validator) evaluateAnd(ctx context.Context, field *cv.Field, node *Node) error {
+	left, right := node.Children[0], node.Children[1]
+	error1 := v.evaluate(ctx, field, left)
+	error2 := v.evaluate(ctx, field, right)
+
+	if error1 != nil || error2 != nil {
+		cause := error1
+		if cause == nil {
+			cause = error2
+		}
+		return operatorError(field, node, cause, "&& validation of %v and %v of Field %v failed", left.SubTag, right.SubTag, getFullFieldName(field))
+	}
+	return nil
+}
+
+func (v *Validator) evaluateOr(ctx context.Context, field *cv.Field, node *Node) error {
+	left, right := node.Children[0], node.Children[1]
+	error1 := v.evaluate(ctx, field, left)
+	error2 := v.evaluate(ctx, field, right)
+
+	if error1 != nil && error2 != nil {
+		return operatorError(field, node, error2, "|| validation of %v or %v of Field %v failed", left.SubTag, right.SubTag, getFullFieldName(field))
+	}
+	return nil
+}
+
+func (v *Validator) evaluateNot(ctx context.Context, field *cv.Field, node *Node) error {
+	if v.evaluate(ctx, field, node.Children[0]) != nil {
+		return nil
+	}
+
+	return operatorError(field, node, nil, "validation of %v of Field %v failed", node.SubTag, getFullFieldName(field))
+}
+
+func (v *Validator) evaluateIf(ctx context.Context, field *cv.Field, node *Node) error {
+	if v.collectingRemote {
+		// the condition cannot be evaluated without the other validators, so the keys of all branches are collected
+		for _, child := range node.Children {
+			_ = v.evaluate(ctx, field, child)
+		}
+		return nil
+	}
+
+	conditionErr := v.evaluate(ctx, field, node.Children[0])
+	if conditionErr == nil {
+		return v.evaluate(ctx, field, node.Children[1])
+	}
+
+	if len(node.Children) > 2 {
+		// evaluate elif or else statement
+		return v.evaluate(ctx, field, node.Children[2])
+	}
+
+	return nil
+}
+
+func (v *Validator) evaluateAlias(ctx context.Context, field *cv.Field, node *Node) error {
+	err := v.evaluate(ctx, field, node.Children[0])
+	if err != nil {
+		return operatorError(field, node, err, "validation of alias %v of Field %v failed: %v", node.SubTag, getFullFieldName(field), err)
+	}
+
+	return nil
+}
+
+func (v *Validator) evaluateDive(ctx context.Context, field *cv.Field, node *Node) error {
+	fValue := field.Value
+	kind := fValue.Kind()
+
+	for kind == reflect.Interface || kind == reflect.Ptr {
+		if fValue.IsNil() {
+			// there are no elements to dive into
+			return nil
+		}
+
+		fValue = fValue.Elem()
+		kind = fValue.Kind()
+	}
+
+	switch kind {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < fValue.Len(); i++ {
+			err := v.evaluate(ctx, elemField(field, i, fValue.Index(i)), node.Children[0])
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := fValue.MapRange()
+		for iter.Next() {
+			err := v.evaluate(ctx, elemField(field, iter.Key(), iter.Value()), node.Children[0])
+			if err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("dive validation of Field %v of kind %v is not supported", getFullFieldName(field), kind)
+	}
+
+	return nil
+}
+
+// elemField creates a field for an element of a slice, array or map field.
+// The element shares the parent of the field and is named after the field and its index or key e.g. Tags[0].
+func elemField(field *cv.Field, key interface{}, value reflect.Value) *cv.Field {
+	structField := field.StructField
+	structField.Name = fmt.Sprintf("%v[%v]", structField.Name, key)
+	structField.Type = value.Type()
+
+	return &cv.Field{
+		Parent:      field.Parent,
+		StructField: structField,
+		Value:       value,
+		Struct:      field.Struct,
+	}
+}
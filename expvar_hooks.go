@@ -0,0 +1,165 @@
+package validator
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the upper bounds of the buckets of latency histograms
+var DefaultLatencyBuckets = []time.Duration{
+	10 * time.Microsecond,
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+}
+
+// ExpvarHooks export counters and latency histograms of validations via expvar.
+// The variables are published as map by the provided name and contain maps of
+//   - validations, validation_failures and validation_latency by the names of the validated types,
+//   - field_failures by the names of the validated types and the paths of the fields without indices and keys
+//     e.g. main.User.Address.Street or main.Order.Items[].Name,
+//   - validator_calls, validator_failures and validator_latency by the ids of the custom validators.
+type ExpvarHooks struct {
+	NopHooks
+
+	// Vars contains the published variables
+	Vars *expvar.Map
+
+	mu                 sync.Mutex
+	buckets            []time.Duration
+	validations        *expvar.Map
+	validationFailures *expvar.Map
+	validationLatency  *expvar.Map
+	fieldFailures      *expvar.Map
+	validatorCalls     *expvar.Map
+	validatorFailures  *expvar.Map
+	validatorLatency   *expvar.Map
+}
+
+// NewExpvarHooks creates new hooks exporting the metrics of validations via expvar by the provided name.
+// Latency histograms use the DefaultLatencyBuckets if no buckets are provided.
+// Panics like expvar.Publish if the name is already in use.
+func NewExpvarHooks(name string, buckets ...time.Duration) *ExpvarHooks {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+
+	hooks := &ExpvarHooks{
+		Vars:               expvar.NewMap(name),
+		buckets:            buckets,
+		validations:        new(expvar.Map).Init(),
+		validationFailures: new(expvar.Map).Init(),
+		validationLatency:  new(expvar.Map).Init(),
+		fieldFailures:      new(expvar.Map).Init(),
+		validatorCalls:     new(expvar.Map).Init(),
+		validatorFailures:  new(expvar.Map).Init(),
+		validatorLatency:   new(expvar.Map).Init(),
+	}
+
+	hooks.Vars.Set("validations", hooks.validations)
+	hooks.Vars.Set("validation_failures", hooks.validationFailures)
+	hooks.Vars.Set("validation_latency", hooks.validationLatency)
+	hooks.Vars.Set("field_failures", hooks.fieldFailures)
+	hooks.Vars.Set("validator_calls", hooks.validatorCalls)
+	hooks.Vars.Set("validator_failures", hooks.validatorFailures)
+	hooks.Vars.Set("validator_latency", hooks.validatorLatency)
+
+	return hooks
+}
+
+// OnValidateEnd counts the validation and its failure and records its latency
+func (hooks *ExpvarHooks) OnValidateEnd(_ context.Context, validation Validation) {
+	hooks.validations.Add(validation.Type, 1)
+	if validation.Err != nil {
+		hooks.validationFailures.Add(validation.Type, 1)
+	}
+	hooks.histogram(hooks.validationLatency, validation.Type).Observe(validation.Duration)
+}
+
+// OnFieldResult counts the failure of a field
+func (hooks *ExpvarHooks) OnFieldResult(_ context.Context, result FieldResult) {
+	if result.Err != nil {
+		hooks.fieldFailures.Add(fmt.Sprintf("%v.%v", result.Type, result.StructPath), 1)
+	}
+}
+
+// OnValidatorCall counts the call of a custom validator and its failure and records its latency
+func (hooks *ExpvarHooks) OnValidatorCall(_ context.Context, call ValidatorCall) {
+	hooks.validatorCalls.Add(call.Validator, 1)
+	if call.Err != nil {
+		hooks.validatorFailures.Add(call.Validator, 1)
+	}
+	hooks.histogram(hooks.validatorLatency, call.Validator).Observe(call.Duration)
+}
+
+// histogram returns the histogram of a key of a map and creates it if it does not exist yet
+func (hooks *ExpvarHooks) histogram(m *expvar.Map, key string) *LatencyHistogram {
+	if histogram, ok := m.Get(key).(*LatencyHistogram); ok {
+		return histogram
+	}
+
+	// expvar.Map has no atomic get or set, so histograms are created while locked
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+
+	if histogram, ok := m.Get(key).(*LatencyHistogram); ok {
+		return histogram
+	}
+
+	histogram := NewLatencyHistogram(hooks.buckets...)
+	m.Set(key, histogram)
+
+	return histogram
+}
+
+// LatencyHistogram counts durations in buckets by their upper bounds.
+// Implements expvar.Var interface
+type LatencyHistogram struct {
+	mu      sync.Mutex
+	buckets []time.Duration
+	counts  []int64
+	count   int64
+	sum     time.Duration
+}
+
+// NewLatencyHistogram creates a new histogram with the provided upper bounds of its buckets in ascending order
+func NewLatencyHistogram(buckets ...time.Duration) *LatencyHistogram {
+	return &LatencyHistogram{
+		buckets: buckets,
+		counts:  make([]int64, len(buckets)),
+	}
+}
+
+// Observe adds a duration to all buckets whose upper bound is greater than or equal to it
+func (h *LatencyHistogram) Observe(duration time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, bound := range h.buckets {
+		if duration <= bound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += duration
+}
+
+// String returns the histogram as JSON object containing the count and sum in nanoseconds of all durations
+// and the cumulative counts of the buckets by their upper bounds e.g. {"count": 2, "sum": 1500000, "buckets": {"1ms": 1, "10ms": 2}}
+func (h *LatencyHistogram) String() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	buckets := make([]string, len(h.buckets))
+	for i, bound := range h.buckets {
+		buckets[i] = fmt.Sprintf("%q: %v", bound.String(), h.counts[i])
+	}
+
+	return fmt.Sprintf(`{"count": %v, "sum": %v, "buckets": {%v}}`, h.count, int64(h.sum), strings.Join(buckets, ", "))
+}
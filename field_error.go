@@ -0,0 +1,130 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// LabelTagKey is the struct field tag key containing the display name of a field that is used in translated messages
+const LabelTagKey = "label"
+
+// FieldError is returned by the validation if a field fails a sub tag or an operator of its tag.
+// Its message is the message of the failed custom validator or operator and can be translated by Translate.
+type FieldError struct {
+	// Path is the full name of the field e.g. Address.Street or Tags[0]
+	Path string
+	// Label is the display name of the field from its label tag or the name of the field
+	Label string
+	// Validator is the ID of the failed custom validator or the kind of the failed operator: and, or, not or alias
+	Validator string
+	// SubTag is the failed part of the tag e.g. len(9)
+	SubTag string
+	// Params contains the arguments of the failed sub tag e.g. New York for oneof('New York')
+	Params []string
+	// Value is the dereferenced value of the field or nil if the field is nil or cannot be accessed
+	Value interface{}
+	// Kind is the kind of the dereferenced field
+	Kind reflect.Kind
+	// Err is the cause of the failure: the error of a custom validator or the error of the failed operand of an operator
+	Err error
+	// MessageKey is the message of the tag that replaced the message of the error, which is used as message key by Translate
+	MessageKey string
+
+	message string
+}
+
+// Error returns the error message string
+// Implements error interface
+func (err *FieldError) Error() string {
+	return err.message
+}
+
+// Unwrap returns the cause of the failure
+func (err *FieldError) Unwrap() error {
+	return err.Err
+}
+
+// ValidationErrors contains the errors of multiple fields
+type ValidationErrors []*FieldError
+
+// Error returns the messages of all errors separated by semicolons
+// Implements error interface
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// NewFieldError creates a field error of the field with the provided path and label whose message is the message of its cause.
+// It can be used to report errors of fields that occur outside of the validation e.g. errors of the conversion of request parameters.
+func NewFieldError(path string, label string, validator string, cause error) *FieldError {
+	return &FieldError{
+		Path:      path,
+		Label:     label,
+		Validator: validator,
+		Err:       cause,
+		message:   cause.Error(),
+	}
+}
+
+// newFieldError creates a field error of a field that failed a validation node
+func newFieldError(field *cv.Field, validator string, node *Node, validationCtx *cv.ValidationContext, cause error, message string) *FieldError {
+	fieldErr := &FieldError{
+		Path:      getFullFieldName(field),
+		Label:     fieldLabel(field),
+		Validator: validator,
+		SubTag:    node.SubTag,
+		Kind:      getUnderlyingType(field.StructField.Type).Kind(),
+		Err:       cause,
+		message:   message,
+	}
+
+	if validationCtx != nil {
+		for _, arg := range validationCtx.Args {
+			fieldErr.Params = append(fieldErr.Params, arg.String())
+		}
+	}
+
+	if value, ok := cv.Deref(field.Value); ok {
+		fieldErr.Kind = value.Kind()
+		if value.CanInterface() {
+			fieldErr.Value = value.Interface()
+		}
+	}
+
+	return fieldErr
+}
+
+// operatorError creates a field error of a failed operator whose cause is the error of a failed operand
+func operatorError(field *cv.Field, node *Node, cause error, format string, a ...interface{}) *FieldError {
+	return newFieldError(field, string(node.Kind), node, nil, cause, fmt.Sprintf(format, a...))
+}
+
+// withMessage replaces the message of the error of a node by the message of the node
+func withMessage(err error, field *cv.Field, node *Node) *FieldError {
+	fieldErr, ok := err.(*FieldError)
+	if !ok {
+		fieldErr = newFieldError(field, string(node.Kind), node, nil, err, err.Error())
+	}
+
+	withMessage := *fieldErr
+	withMessage.MessageKey = node.Message
+	withMessage.message = node.Message
+
+	return &withMessage
+}
+
+// fieldLabel returns the label tag of a field or its name
+func fieldLabel(field *cv.Field) string {
+	if label, ok := field.StructField.Tag.Lookup(LabelTagKey); ok {
+		return label
+	}
+
+	return field.StructField.Name
+}
@@ -0,0 +1,229 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// FieldRules contains validation rules for a single field that are defined in code instead of struct field tags
+type FieldRules struct {
+	fieldPtr interface{}
+	node     *Node
+}
+
+// Field defines validation rules for the field the provided pointer points to.
+// All rules have to succeed. The rules can be created by the rules package and are validated in addition to the tags of the field.
+// Usage:
+//
+//	err := validator.ValidateStruct(ctx, &s,
+//		validator.Field(&s.Name, rules.Required(), rules.Len(9)),
+//	)
+func Field(fieldPtr interface{}, rules ...*Node) *FieldRules {
+	var node *Node
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i] == nil {
+			continue
+		}
+
+		if node == nil {
+			node = rules[i]
+		} else {
+			node = NewAndNode(rules[i], node)
+		}
+	}
+
+	return &FieldRules{
+		fieldPtr: fieldPtr,
+		node:     node,
+	}
+}
+
+// ValidateStruct validates the struct the provided pointer points to by its struct field tags and the provided field rules.
+// Returns an error if the validation failed or nil otherwise.
+func (v *Validator) ValidateStruct(ctx context.Context, structPtr interface{}, fields ...*FieldRules) error {
+	ptrValue := reflect.ValueOf(structPtr)
+	if ptrValue.Kind() != reflect.Ptr || ptrValue.IsNil() || ptrValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("validation of struct rules requires a non-nil pointer to a struct but got %T", structPtr)
+	}
+	structValue := ptrValue.Elem()
+
+	rules := &structRules{}
+	for _, field := range fields {
+		path, err := getFieldPath(structValue, field.fieldPtr)
+		if err != nil {
+			return err
+		}
+
+		if field.node == nil {
+			continue
+		}
+
+		rules.add(path, field.node)
+	}
+
+	ctx, end := v.observeValidation(ctx, structValue.Type())
+	err := v.validateStructRules(ctx, structValue, rules)
+	end(err)
+
+	return err
+}
+
+// validateStructRules validates a struct and the rules defined in code for its fields and converts panics into a PanicError
+func (v *Validator) validateStructRules(ctx context.Context, structValue reflect.Value, rules *structRules) (err error) {
+	defer recoverPanic(&err)
+
+	ctx = v.prefetchRemote(ctx, func(collector *Validator, ctx context.Context) {
+		_ = collector.validateStructRules(ctx, structValue, rules)
+	})
+
+	return v.localize(ctx, v.validateStructWithRules(ctx, structValue, nil, rules))
+}
+
+// compileRules expands and binds a copy of the expression tree of field rules.
+// Validators created by NewValidator cache the compiled rules by the struct type and the expression of the rules,
+// since Field creates new expression trees for every call. Rules that cannot be compiled are not cached.
+func (v *Validator) compileRules(structType reflect.Type, node *Node) (*Node, error) {
+	if v.plans == nil {
+		return v.compile(structType, node.clone())
+	}
+
+	key := ruleKey{structType: structType, expression: node.expression()}
+	if compiled, ok := v.plans.getRules(key); ok {
+		return compiled, nil
+	}
+
+	compiled, err := v.compile(structType, node.clone())
+	if err != nil {
+		return nil, err
+	}
+
+	v.plans.setRules(key, compiled)
+
+	return compiled, nil
+}
+
+// structRules contains the rules defined in code for the fields of a struct and its nested structs by the indexes of the fields
+type structRules struct {
+	fields map[int]*Node
+	nested map[int]*structRules
+}
+
+// add adds the rules of the field with the provided index path e.g. [1 0] for the first field of the struct in the second field
+func (rules *structRules) add(path []int, node *Node) {
+	for ; len(path) > 1; path = path[1:] {
+		if rules.nested == nil {
+			rules.nested = map[int]*structRules{}
+		}
+
+		nested, ok := rules.nested[path[0]]
+		if !ok {
+			nested = &structRules{}
+			rules.nested[path[0]] = nested
+		}
+		rules = nested
+	}
+
+	if rules.fields == nil {
+		rules.fields = map[int]*Node{}
+	}
+
+	index := path[0]
+	if rules.fields[index] == nil {
+		rules.fields[index] = node
+	} else {
+		rules.fields[index] = NewAndNode(rules.fields[index], node)
+	}
+}
+
+// field returns the rules of the field with the provided index or nil
+func (rules *structRules) field(index int) *Node {
+	if rules == nil {
+		return nil
+	}
+
+	return rules.fields[index]
+}
+
+// nestedRules returns the rules of the struct in the field with the provided index or nil
+func (rules *structRules) nestedRules(index int) *structRules {
+	if rules == nil {
+		return nil
+	}
+
+	return rules.nested[index]
+}
+
+// getFieldPath returns the index path of the struct field the provided pointer points to.
+// Fields of nested structs are found by the address ranges of the nested structs and the non-nil pointers to them.
+func getFieldPath(structValue reflect.Value, fieldPtr interface{}) ([]int, error) {
+	ptrValue := reflect.ValueOf(fieldPtr)
+	if ptrValue.Kind() != reflect.Ptr || ptrValue.IsNil() {
+		return nil, fmt.Errorf("field rules require a non-nil pointer to a field but got %T", fieldPtr)
+	}
+
+	visited := map[visitedStruct]bool{{addr: structValue.Addr().Pointer(), rType: structValue.Type()}: true}
+	path, ok := findFieldPath(structValue, ptrValue.Pointer(), ptrValue.Elem().Type(), visited)
+	if !ok {
+		return nil, fmt.Errorf("pointer of type %T does not point to a field of struct %v", fieldPtr, structValue.Type())
+	}
+
+	return path, nil
+}
+
+// visitedStruct identifies a struct by its address and type, since a struct shares its address with its first field
+type visitedStruct struct {
+	addr  uintptr
+	rType reflect.Type
+}
+
+// findFieldPath searches the field at the address of the provided type in a struct and its nested structs
+// while visited contains the structs behind pointers that have been searched already
+func findFieldPath(structValue reflect.Value, addr uintptr, fieldType reflect.Type, visited map[visitedStruct]bool) ([]int, bool) {
+	for i := 0; i < structValue.NumField(); i++ {
+		fieldValue := structValue.Field(i)
+		fieldAddr := fieldValue.Addr().Pointer()
+
+		// the first field shares its address with the struct, therefore the type has to be compared as well
+		if fieldAddr == addr && fieldValue.Type() == fieldType {
+			return []int{i}, true
+		}
+
+		nested := fieldValue
+		if nested.Kind() == reflect.Ptr && !nested.IsNil() {
+			nested = nested.Elem()
+			key := visitedStruct{addr: nested.Addr().Pointer(), rType: nested.Type()}
+			if visited[key] {
+				continue
+			}
+			visited[key] = true
+		}
+
+		if nested.Kind() != reflect.Struct {
+			continue
+		}
+
+		start := nested.Addr().Pointer()
+		if addr < start || addr >= start+nested.Type().Size() {
+			continue
+		}
+
+		if path, ok := findFieldPath(nested, addr, fieldType, visited); ok {
+			return append([]int{i}, path...), true
+		}
+	}
+
+	return nil, false
+}
+
+// runFieldRules validates the rules of a field that have been defined in code
+func (v *Validator) runFieldRules(ctx context.Context, field *cv.Field, rules *Node) error {
+	node, err := v.compileRules(field.Struct.Type(), rules)
+	if err != nil {
+		return withFieldPath(err, field)
+	}
+
+	return v.evaluate(ctx, field, node)
+}
@@ -0,0 +1,168 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type FieldRulesStruct struct {
+	First  string `validator:"email"`
+	Second int
+}
+
+func TestValidator_ValidateStruct(t *testing.T) {
+	validator := NewValidator()
+	s := FieldRulesStruct{First: ValidEmail, Second: 3}
+
+	err := validator.ValidateStruct(context.Background(), &s,
+		Field(&s.First, NewValidationNode("len(13)")),
+		Field(&s.Second, NewValidationNode("min(3)"), NewValidationNode("max(3)")),
+	)
+
+	assert.NoError(t, err)
+}
+
+func TestValidator_ValidateStruct_combinesTagsAndRules(t *testing.T) {
+	validator := NewValidator()
+	s := FieldRulesStruct{First: "test@test.de"}
+
+	err := validator.ValidateStruct(context.Background(), &s, Field(&s.First, NewValidationNode("len(13)")))
+	assert.EqualError(t, err, "len field First has length 12, but should have length 13")
+
+	s = FieldRulesStruct{First: "1234567890123"}
+
+	err = validator.ValidateStruct(context.Background(), &s, Field(&s.First, NewValidationNode("len(13)")))
+	assert.EqualError(t, err, "email field First is no valid email")
+}
+
+func TestValidator_ValidateStruct_distinguishesFirstFieldFromStruct(t *testing.T) {
+	type nested struct {
+		Inner FieldRulesStruct
+	}
+	validator := NewValidator()
+	s := nested{Inner: FieldRulesStruct{First: ValidEmail}}
+
+	// the rules of the struct apply to the struct and not to its first field
+	err := validator.ValidateStruct(context.Background(), &s, Field(&s.Inner, NewValidationNode("len(13)")))
+	assert.EqualError(t, err, "len field Inner is of kind struct")
+
+	err = validator.ValidateStruct(context.Background(), &s, Field(&s.Inner.First, NewValidationNode("len(13)")))
+	assert.NoError(t, err)
+}
+
+type FieldRulesParent struct {
+	Name   string
+	Inner  FieldRulesStruct
+	Child  *FieldRulesChild
+	Values []FieldRulesStruct
+}
+
+type FieldRulesChild struct {
+	Name  string
+	Inner FieldRulesStruct
+}
+
+func TestValidator_ValidateStruct_nestedFields(t *testing.T) {
+	validator := NewValidator()
+	s := FieldRulesParent{
+		Inner: FieldRulesStruct{First: ValidEmail, Second: 1},
+		Child: &FieldRulesChild{Name: "ab", Inner: FieldRulesStruct{First: ValidEmail, Second: 5}},
+	}
+
+	err := validator.ValidateStruct(context.Background(), &s,
+		Field(&s.Inner.First, NewValidationNode("len(13)")),
+		Field(&s.Inner.Second, NewValidationNode("min(1)")),
+		Field(&s.Child.Name, NewValidationNode("len(2)")),
+	)
+	assert.NoError(t, err)
+
+	err = validator.ValidateStruct(context.Background(), &s, Field(&s.Inner.Second, NewValidationNode("min(2)")))
+	assert.EqualError(t, err, "min field Second has size 1, but should have at least size 2")
+
+	err = validator.ValidateStruct(context.Background(), &s, Field(&s.Child.Name, NewValidationNode("len(3)")))
+	assert.EqualError(t, err, "len field Name has length 2, but should have length 3")
+
+	var fieldErr *FieldError
+	err = validator.ValidateStruct(context.Background(), &s, Field(&s.Child.Inner.Second, NewValidationNode("max(3)")))
+	if assert.True(t, errors.As(err, &fieldErr)) {
+		assert.Equal(t, "Child.Inner.Second", fieldErr.Path)
+	}
+
+	// elements of slices are no fields of the struct
+	s.Values = []FieldRulesStruct{{}}
+	err = validator.ValidateStruct(context.Background(), &s, Field(&s.Values[0].First, NewValidationNode("len(3)")))
+	assert.EqualError(t, err, "pointer of type *string does not point to a field of struct validator.FieldRulesParent")
+}
+
+func TestGetFieldPath(t *testing.T) {
+	type cyclic struct {
+		Self  *cyclic
+		Inner FieldRulesStruct
+		Name  string
+	}
+	s := &cyclic{}
+	s.Self = s
+	other := &cyclic{Self: s}
+
+	path, err := getFieldPath(reflect.ValueOf(s).Elem(), &s.Inner.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 1}, path)
+
+	path, err = getFieldPath(reflect.ValueOf(other).Elem(), &s.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 2}, path)
+
+	_, err = getFieldPath(reflect.ValueOf(s).Elem(), &other.Name)
+	assert.Error(t, err)
+}
+
+func TestValidator_ValidateStruct_failsForInvalidPointers(t *testing.T) {
+	validator := NewValidator()
+	s := FieldRulesStruct{First: ValidEmail}
+	other := ""
+
+	err := validator.ValidateStruct(context.Background(), s)
+	assert.Error(t, err)
+
+	err = validator.ValidateStruct(context.Background(), &s, Field(&other, NewValidationNode("len(13)")))
+	assert.Error(t, err)
+
+	err = validator.ValidateStruct(context.Background(), &s, Field(&s.Second, NewValidationNode("len(abc)")))
+	assert.IsType(t, &TagSyntaxError{}, err)
+}
+
+func TestValidator_ValidateStruct_cachesCompiledRules(t *testing.T) {
+	validator := NewValidator()
+	s := FieldRulesStruct{First: ValidEmail, Second: 3}
+	structType := reflect.TypeOf(s)
+
+	for i := 0; i < 2; i++ {
+		err := validator.ValidateStruct(context.Background(), &s, Field(&s.Second, NewValidationNode("min(3)"), NewValidationNode("max(3)")))
+		assert.NoError(t, err)
+	}
+	assert.Len(t, validator.plans.rules, 1)
+
+	rules := NewAndNode(NewValidationNode("min(3)"), NewValidationNode("max(3)"))
+	compiled, ok := validator.plans.getRules(ruleKey{structType: structType, expression: rules.expression()})
+	assert.True(t, ok)
+	assert.NotSame(t, rules, compiled)
+
+	// a message of a nested node changes the expression of the rules
+	withMessage := NewValidationNode("min(4)")
+	withMessage.Message = "second.min"
+	err := validator.ValidateStruct(context.Background(), &s, Field(&s.Second, withMessage, NewValidationNode("max(3)")))
+	assert.Error(t, err)
+	assert.Len(t, validator.plans.rules, 2)
+
+	// rules that cannot be compiled are not cached
+	err = validator.ValidateStruct(context.Background(), &s, Field(&s.Second, NewValidationNode("len(abc)")))
+	assert.IsType(t, &TagSyntaxError{}, err)
+	assert.Len(t, validator.plans.rules, 2)
+
+	assert.NoError(t, validator.RegisterMacro("positive", "min(1)"))
+	assert.Empty(t, validator.plans.rules)
+}
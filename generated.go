@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// CheckGeneratedTags checks that the validator tags of a struct still match the tags its Validate method has been generated from.
+// The generated tags contain the validator tag of every field of the struct by its name, fields without tag are mapped to an empty string and blank fields are ignored.
+// Returns an error if a field has been added, removed or its tag has been changed since the code has been generated.
+func CheckGeneratedTags(i interface{}, generatedTags map[string]string) error {
+	structType := getUnderlyingType(reflect.TypeOf(i))
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("generated validation of kind %v is not supported", structType.Kind())
+	}
+
+	for j := 0; j < structType.NumField(); j++ {
+		structField := structType.Field(j)
+		if structField.Name == "_" {
+			// blank fields cannot be validated by generated code
+			continue
+		}
+
+		generatedTag, ok := generatedTags[structField.Name]
+		if !ok {
+			return fmt.Errorf("generated validation of %v is out of sync: field %v has been added, run go generate", structType, structField.Name)
+		}
+
+		tag := structField.Tag.Get(DefaultTagKey)
+		if tag != generatedTag {
+			return fmt.Errorf("generated validation of %v is out of sync: field %v has tag %q but the code has been generated for %q, run go generate", structType, structField.Name, tag, generatedTag)
+		}
+	}
+
+	for name := range generatedTags {
+		if _, ok := structType.FieldByName(name); !ok {
+			return fmt.Errorf("generated validation of %v is out of sync: field %v has been removed, run go generate", structType, name)
+		}
+	}
+
+	return nil
+}
+
+// MustCheckGeneratedTags is like CheckGeneratedTags but panics if the tags are out of sync.
+// It is called by the init function of code generated by validator-gen.
+func MustCheckGeneratedTags(i interface{}, generatedTags map[string]string) {
+	err := CheckGeneratedTags(i, generatedTags)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MustValidationContext creates the validation context of a sub tag for the field with the provided name and panics if the sub tag is invalid.
+// It is used by code generated by validator-gen to parse the arguments of sub tags once.
+func MustValidationContext(customValidator *cv.CustomValidator, subTag string, fieldName string) *cv.ValidationContext {
+	validationCtx, err := customValidator.NewValidationContext(subTag)
+	if err != nil {
+		panic(SyntaxErrorf("invalid arguments for validation %v: %v", subTag, err).WithField("validator", customValidator.ID))
+	}
+	validationCtx.FieldName = fieldName
+
+	return validationCtx
+}
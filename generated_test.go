@@ -0,0 +1,58 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator/dv"
+)
+
+type GeneratedStruct struct {
+	Name  string `validator:"required && len(9)"`
+	Email string
+	_     int
+}
+
+func TestCheckGeneratedTags(t *testing.T) {
+	err := CheckGeneratedTags(&GeneratedStruct{}, map[string]string{"Name": "required && len(9)", "Email": ""})
+	assert.NoError(t, err)
+
+	err = CheckGeneratedTags(GeneratedStruct{}, map[string]string{"Name": "required", "Email": ""})
+	assert.EqualError(t, err, `generated validation of validator.GeneratedStruct is out of sync: field Name has tag "required && len(9)" but the code has been generated for "required", run go generate`)
+
+	err = CheckGeneratedTags(GeneratedStruct{}, map[string]string{"Name": "required && len(9)"})
+	assert.EqualError(t, err, "generated validation of validator.GeneratedStruct is out of sync: field Email has been added, run go generate")
+
+	err = CheckGeneratedTags(GeneratedStruct{}, map[string]string{"Name": "required && len(9)", "Email": "", "Phone": ""})
+	assert.EqualError(t, err, "generated validation of validator.GeneratedStruct is out of sync: field Phone has been removed, run go generate")
+}
+
+func TestMustCheckGeneratedTags(t *testing.T) {
+	assert.Panics(t, func() {
+		MustCheckGeneratedTags(GeneratedStruct{}, map[string]string{})
+	})
+}
+
+func TestMustValidationContext(t *testing.T) {
+	validationCtx := MustValidationContext(dv.Len(), "len(9)", "Name")
+	assert.Equal(t, "Name", validationCtx.FieldName)
+	assert.Equal(t, 9, validationCtx.Arg(0).Int())
+
+	assert.Panics(t, func() {
+		MustValidationContext(dv.Len(), "len(nine)", "Name")
+	})
+}
+
+func TestValidator_CompileTag(t *testing.T) {
+	node, err := NewValidator().CompileTag("required && len(9)")
+	assert.NoError(t, err)
+
+	customValidators := node.Children[1].CustomValidators()
+	if assert.Len(t, customValidators, 1) {
+		assert.Equal(t, "len", customValidators[0].ID)
+	}
+
+	_, err = NewValidator().CompileTag("len(nine)")
+	assert.Error(t, err)
+}
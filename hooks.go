@@ -0,0 +1,259 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// Hooks observe validations e.g. to export metrics. The hooks are called synchronously during the validation
+// and must be safe for concurrent use if the validator is used concurrently.
+// NopHooks can be embedded to implement only some of the hooks.
+type Hooks interface {
+	// OnValidateStart is called before a validation and returns the context of the validation
+	OnValidateStart(ctx context.Context, validation Validation) context.Context
+	// OnValidateEnd is called after a validation with its error and duration
+	OnValidateEnd(ctx context.Context, validation Validation)
+	// OnFieldResult is called after the tags and rules of a field have been evaluated
+	OnFieldResult(ctx context.Context, result FieldResult)
+	// OnValidatorCall is called after a custom validator has validated a field
+	OnValidatorCall(ctx context.Context, call ValidatorCall)
+}
+
+// Validation describes a call of Validate, ValidateAll or ValidateStruct
+type Validation struct {
+	// Type is the name of the validated type e.g. main.User
+	Type string
+	// Err is the error of the validation. It is always nil for OnValidateStart.
+	Err error
+	// Duration is the duration of the validation. It is always zero for OnValidateStart.
+	Duration time.Duration
+}
+
+// FieldResult describes the evaluation of the tags and rules of a field
+type FieldResult struct {
+	// Type is the name of the validated type containing the field
+	Type string
+	// Path is the full name of the field e.g. Address.Street
+	Path string
+	// StructPath is the full name of the field without the indices and keys of slices, arrays and maps e.g. Items[].Name,
+	// which is bounded by the struct type unlike the path
+	StructPath string
+	Err        error
+	Duration   time.Duration
+}
+
+// ValidatorCall describes the validation of a field by a custom validator
+type ValidatorCall struct {
+	// Type is the name of the validated type containing the field
+	Type string
+	// Path is the full name of the field e.g. Tags[0]
+	Path string
+	// Validator is the id of the custom validator
+	Validator string
+	SubTag    string
+	Err       error
+	Duration  time.Duration
+}
+
+// NopHooks implements all hooks without doing anything
+type NopHooks struct{}
+
+// OnValidateStart returns the context unchanged
+func (NopHooks) OnValidateStart(ctx context.Context, _ Validation) context.Context { return ctx }
+
+// OnValidateEnd does nothing
+func (NopHooks) OnValidateEnd(context.Context, Validation) {}
+
+// OnFieldResult does nothing
+func (NopHooks) OnFieldResult(context.Context, FieldResult) {}
+
+// OnValidatorCall does nothing
+func (NopHooks) OnValidatorCall(context.Context, ValidatorCall) {}
+
+type chainedHooks []Hooks
+
+// ChainHooks combines multiple hooks, which are called in the provided order
+func ChainHooks(hooks ...Hooks) Hooks {
+	return chainedHooks(hooks)
+}
+
+func (chain chainedHooks) OnValidateStart(ctx context.Context, validation Validation) context.Context {
+	for _, hooks := range chain {
+		ctx = hooks.OnValidateStart(ctx, validation)
+	}
+
+	return ctx
+}
+
+func (chain chainedHooks) OnValidateEnd(ctx context.Context, validation Validation) {
+	for _, hooks := range chain {
+		hooks.OnValidateEnd(ctx, validation)
+	}
+}
+
+func (chain chainedHooks) OnFieldResult(ctx context.Context, result FieldResult) {
+	for _, hooks := range chain {
+		hooks.OnFieldResult(ctx, result)
+	}
+}
+
+func (chain chainedHooks) OnValidatorCall(ctx context.Context, call ValidatorCall) {
+	for _, hooks := range chain {
+		hooks.OnValidatorCall(ctx, call)
+	}
+}
+
+// Names of the spans started by the Tracer of a validator
+const (
+	// SpanValidate is the name of the span of a call of Validate, ValidateAll or ValidateStruct
+	SpanValidate = "validator.validate"
+	// SpanField is the name of the span of the evaluation of the tags and rules of a field
+	SpanField = "validator.field"
+	// SpanValidatorCall is the name of the span of the validation of a field by a custom validator
+	SpanValidatorCall = "validator.call"
+)
+
+// Tracer starts the spans of validations, which can be bridged to any tracing library.
+// The spans of fields are children of the span of their validation and the spans of custom validators are children of the span of their field.
+type Tracer interface {
+	// Start starts a span and returns a context containing it
+	Start(ctx context.Context, name string, attributes SpanAttributes) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer
+type Span interface {
+	// End ends the span with the error of the traced operation or nil if it succeeded
+	End(err error)
+}
+
+// SpanAttributes identify the traced operation. Attributes that do not apply to a span are empty e.g. the Path of a validation.
+type SpanAttributes struct {
+	Type      string
+	Path      string
+	Validator string
+	SubTag    string
+}
+
+type observationKey struct{}
+
+// observation contains the name of the validated type for the hooks and spans of fields and custom validators
+type observation struct {
+	typeName string
+}
+
+// observed returns whether the validation is observed by hooks or a tracer
+func (v *Validator) observed() bool {
+	return v.Hooks != nil || v.Tracer != nil
+}
+
+// observeValidation starts the observation of a validation of the provided type.
+// Returns the context of the validation and a function ending the observation with the error of the validation.
+func (v *Validator) observeValidation(ctx context.Context, rType reflect.Type) (context.Context, func(err error)) {
+	if !v.observed() {
+		return ctx, func(error) {}
+	}
+
+	typeName := "<nil>"
+	if rType != nil {
+		typeName = getUnderlyingType(rType).String()
+	}
+
+	if v.Hooks != nil {
+		ctx = v.Hooks.OnValidateStart(ctx, Validation{Type: typeName})
+	}
+	ctx, span := v.startSpan(ctx, SpanValidate, SpanAttributes{Type: typeName})
+	ctx = context.WithValue(ctx, observationKey{}, &observation{typeName: typeName})
+
+	start := time.Now()
+	return ctx, func(err error) {
+		duration := time.Since(start)
+		span.End(err)
+
+		if v.Hooks != nil {
+			v.Hooks.OnValidateEnd(ctx, Validation{Type: typeName, Err: err, Duration: duration})
+		}
+	}
+}
+
+// observeField starts the observation of the evaluation of the tags and rules of a field
+func (v *Validator) observeField(ctx context.Context, field *cv.Field) (context.Context, func(err error)) {
+	obs, ok := ctx.Value(observationKey{}).(*observation)
+	if !ok || !v.observed() {
+		return ctx, func(error) {}
+	}
+
+	path := getFullFieldName(field)
+	ctx, span := v.startSpan(ctx, SpanField, SpanAttributes{Type: obs.typeName, Path: path})
+
+	start := time.Now()
+	return ctx, func(err error) {
+		duration := time.Since(start)
+		span.End(err)
+
+		if v.Hooks != nil {
+			v.Hooks.OnFieldResult(ctx, FieldResult{Type: obs.typeName, Path: path, StructPath: getStructFieldPath(field), Err: err, Duration: duration})
+		}
+	}
+}
+
+// getStructFieldPath returns the full name of the field whose indices and keys of elements are replaced by [] e.g. Items[].Name
+func getStructFieldPath(field *cv.Field) string {
+	var names []string
+	for ; field != nil; field = field.Parent {
+		name := field.StructField.Name
+		if i := strings.IndexByte(name, '['); i >= 0 {
+			// elements are named after their field, whose name is an identifier, and their index or key e.g. Items[1]
+			name = name[:i] + "[]"
+		}
+		names = append([]string{name}, names...)
+	}
+
+	return strings.Join(names, ".")
+}
+
+// callValidator validates a field by a custom validator and observes the call. Panics of the custom validator are converted into a PanicError.
+func (v *Validator) callValidator(ctx context.Context, customValidator *cv.CustomValidator, field *cv.Field, vCtx *cv.ValidationContext) error {
+	defer repanic(field, customValidator.ID)
+
+	obs, ok := ctx.Value(observationKey{}).(*observation)
+	if !ok || !v.observed() {
+		return customValidator.Validate(ctx, field, vCtx)
+	}
+
+	path := getFullFieldName(field)
+	ctx, span := v.startSpan(ctx, SpanValidatorCall, SpanAttributes{Type: obs.typeName, Path: path, Validator: customValidator.ID, SubTag: vCtx.SubTag})
+
+	start := time.Now()
+	err := customValidator.Validate(ctx, field, vCtx)
+	duration := time.Since(start)
+	span.End(err)
+
+	if v.Hooks != nil {
+		v.Hooks.OnValidatorCall(ctx, ValidatorCall{
+			Type:      obs.typeName,
+			Path:      path,
+			Validator: customValidator.ID,
+			SubTag:    vCtx.SubTag,
+			Err:       err,
+			Duration:  duration,
+		})
+	}
+
+	return err
+}
+
+type nopSpan struct{}
+
+func (nopSpan) End(error) {}
+
+func (v *Validator) startSpan(ctx context.Context, name string, attributes SpanAttributes) (context.Context, Span) {
+	if v.Tracer == nil {
+		return ctx, nopSpan{}
+	}
+
+	return v.Tracer.Start(ctx, name, attributes)
+}
@@ -0,0 +1,231 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+type ObservedUser struct {
+	Name    string `validator:"len(3)"`
+	Email   string `validator:"email && len(5)"`
+	Address ObservedAddress
+	Note    string
+}
+
+type ObservedAddress struct {
+	Street string `validator:"non-zero"`
+}
+
+type recordingHooks struct {
+	mu          sync.Mutex
+	events      []string
+	validations []Validation
+}
+
+type startKey struct{}
+
+func (hooks *recordingHooks) record(event string) {
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+
+	hooks.events = append(hooks.events, event)
+}
+
+func (hooks *recordingHooks) OnValidateStart(ctx context.Context, validation Validation) context.Context {
+	hooks.record(fmt.Sprintf("start %v", validation.Type))
+	return context.WithValue(ctx, startKey{}, validation.Type)
+}
+
+func (hooks *recordingHooks) OnValidateEnd(ctx context.Context, validation Validation) {
+	hooks.record(fmt.Sprintf("end %v %v %v", validation.Type, validation.Err != nil, ctx.Value(startKey{})))
+	hooks.validations = append(hooks.validations, validation)
+}
+
+func (hooks *recordingHooks) OnFieldResult(ctx context.Context, result FieldResult) {
+	hooks.record(fmt.Sprintf("field %v.%v %v", result.Type, result.Path, result.Err != nil))
+}
+
+func (hooks *recordingHooks) OnValidatorCall(ctx context.Context, call ValidatorCall) {
+	hooks.record(fmt.Sprintf("call %v.%v %v(%v) %v", call.Type, call.Path, call.Validator, call.SubTag, call.Err != nil))
+}
+
+func TestValidator_Validate_hooks(t *testing.T) {
+	hooks := &recordingHooks{}
+	validator := NewValidator()
+	validator.Hooks = hooks
+
+	err := validator.Validate(context.Background(), &ObservedUser{Name: "abc", Email: "ab@c.de", Address: ObservedAddress{Street: "Main"}})
+	assert.Error(t, err)
+	assert.Equal(t, []string{
+		"start validator.ObservedUser",
+		"call validator.ObservedUser.Name len(len(3)) false",
+		"field validator.ObservedUser.Name false",
+		"call validator.ObservedUser.Email email(email) false",
+		"call validator.ObservedUser.Email len(len(5)) true",
+		"field validator.ObservedUser.Email true",
+		"end validator.ObservedUser true validator.ObservedUser",
+	}, hooks.events)
+	assert.Equal(t, err, hooks.validations[0].Err)
+	assert.Greater(t, hooks.validations[0].Duration, time.Duration(0))
+
+	hooks.events = nil
+	err = validator.ValidateAll(context.Background(), ObservedUser{Name: "ab", Email: "a@b.c"})
+	assert.Error(t, err)
+	assert.Equal(t, []string{
+		"start validator.ObservedUser",
+		"call validator.ObservedUser.Name len(len(3)) true",
+		"field validator.ObservedUser.Name true",
+		"call validator.ObservedUser.Email email(email) false",
+		"call validator.ObservedUser.Email len(len(5)) false",
+		"field validator.ObservedUser.Email false",
+		"call validator.ObservedUser.Address.Street non-zero(non-zero) true",
+		"field validator.ObservedUser.Address.Street true",
+		"end validator.ObservedUser true validator.ObservedUser",
+	}, hooks.events)
+
+	hooks.events = nil
+	user := ObservedUser{Name: "abc", Email: "a@b.c", Address: ObservedAddress{Street: "Main"}, Note: "x"}
+	err = validator.ValidateStruct(context.Background(), &user, Field(&user.Note, NewValidationNode("len(0)")))
+	assert.Error(t, err)
+	assert.Contains(t, hooks.events, "field validator.ObservedUser.Note true")
+	assert.Equal(t, "end validator.ObservedUser true validator.ObservedUser", hooks.events[len(hooks.events)-1])
+}
+
+func TestChainHooks(t *testing.T) {
+	first, second := &recordingHooks{}, &recordingHooks{}
+	validator := NewValidator()
+	validator.Hooks = ChainHooks(first, NopHooks{}, second)
+
+	assert.NoError(t, validator.Validate(context.Background(), ObservedAddress{Street: "Main"}))
+	assert.Equal(t, first.events, second.events)
+	assert.Len(t, first.events, 4)
+}
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []string
+}
+
+type spanKey struct{}
+
+type recordingSpan struct {
+	tracer *recordingTracer
+	name   string
+}
+
+func (tracer *recordingTracer) Start(ctx context.Context, name string, attributes SpanAttributes) (context.Context, Span) {
+	parent, _ := ctx.Value(spanKey{}).(string)
+	span := &recordingSpan{tracer: tracer, name: fmt.Sprintf("%v %v %v %v %v", name, attributes.Type, attributes.Path, attributes.Validator, attributes.SubTag)}
+
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+	tracer.spans = append(tracer.spans, fmt.Sprintf("start [%v] %v", parent, span.name))
+
+	return context.WithValue(ctx, spanKey{}, name), span
+}
+
+func (span *recordingSpan) End(err error) {
+	span.tracer.mu.Lock()
+	defer span.tracer.mu.Unlock()
+	span.tracer.spans = append(span.tracer.spans, fmt.Sprintf("end %v %v", span.name, err != nil))
+}
+
+func TestValidator_Validate_tracer(t *testing.T) {
+	tracer := &recordingTracer{}
+	validator := NewValidator()
+	validator.Tracer = tracer
+
+	assert.Error(t, validator.Validate(context.Background(), ObservedAddress{}))
+	assert.Equal(t, []string{
+		"start [] validator.validate validator.ObservedAddress   ",
+		"start [validator.validate] validator.field validator.ObservedAddress Street  ",
+		"start [validator.field] validator.call validator.ObservedAddress Street non-zero non-zero",
+		"end validator.call validator.ObservedAddress Street non-zero non-zero true",
+		"end validator.field validator.ObservedAddress Street   true",
+		"end validator.validate validator.ObservedAddress    true",
+	}, tracer.spans)
+}
+
+func TestExpvarHooks(t *testing.T) {
+	hooks := NewExpvarHooks("validator_test_hooks", time.Millisecond, time.Hour)
+	validator := NewValidator()
+	validator.Hooks = hooks
+
+	assert.NoError(t, validator.Validate(context.Background(), ObservedAddress{Street: "Main"}))
+	assert.Error(t, validator.Validate(context.Background(), ObservedAddress{}))
+
+	assert.Equal(t, hooks.Vars, expvar.Get("validator_test_hooks"))
+
+	var vars struct {
+		Validations        map[string]int `json:"validations"`
+		ValidationFailures map[string]int `json:"validation_failures"`
+		ValidationLatency  map[string]struct {
+			Count   int            `json:"count"`
+			Buckets map[string]int `json:"buckets"`
+		} `json:"validation_latency"`
+		FieldFailures     map[string]int `json:"field_failures"`
+		ValidatorCalls    map[string]int `json:"validator_calls"`
+		ValidatorFailures map[string]int `json:"validator_failures"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(hooks.Vars.String()), &vars))
+
+	assert.Equal(t, map[string]int{"validator.ObservedAddress": 2}, vars.Validations)
+	assert.Equal(t, map[string]int{"validator.ObservedAddress": 1}, vars.ValidationFailures)
+	assert.Equal(t, 2, vars.ValidationLatency["validator.ObservedAddress"].Count)
+	assert.Equal(t, 2, vars.ValidationLatency["validator.ObservedAddress"].Buckets["1h0m0s"])
+	assert.Equal(t, map[string]int{"validator.ObservedAddress.Street": 1}, vars.FieldFailures)
+	assert.Equal(t, map[string]int{"non-zero": 2}, vars.ValidatorCalls)
+	assert.Equal(t, map[string]int{"non-zero": 1}, vars.ValidatorFailures)
+}
+
+func TestExpvarHooks_fieldFailuresWithoutIndices(t *testing.T) {
+	hooks := NewExpvarHooks("validator_test_hooks_indices")
+
+	for i := 0; i < 3; i++ {
+		hooks.OnFieldResult(context.Background(), FieldResult{
+			Type:       "main.Order",
+			Path:       fmt.Sprintf("Items[%v].Name", i),
+			StructPath: "Items[].Name",
+			Err:        errors.New("invalid"),
+		})
+	}
+
+	var vars struct {
+		FieldFailures map[string]int `json:"field_failures"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(hooks.Vars.String()), &vars))
+	assert.Equal(t, map[string]int{"main.Order.Items[].Name": 3}, vars.FieldFailures)
+}
+
+func TestGetStructFieldPath(t *testing.T) {
+	items := &cv.Field{StructField: reflect.StructField{Name: "Items"}}
+	item := elemField(items, 1234, reflect.ValueOf(ObservedAddress{}))
+	street := &cv.Field{Parent: item, StructField: reflect.StructField{Name: "Street"}}
+	labels := elemField(&cv.Field{Parent: street, StructField: reflect.StructField{Name: "Labels"}}, "a].b[c", reflect.ValueOf(""))
+
+	assert.Equal(t, "Items[1234].Street", getFullFieldName(street))
+	assert.Equal(t, "Items[].Street", getStructFieldPath(street))
+	assert.Equal(t, "Items[].Street.Labels[]", getStructFieldPath(labels))
+}
+
+func TestLatencyHistogram(t *testing.T) {
+	histogram := NewLatencyHistogram(time.Millisecond, 10*time.Millisecond)
+	histogram.Observe(500 * time.Microsecond)
+	histogram.Observe(time.Millisecond)
+	histogram.Observe(5 * time.Millisecond)
+	histogram.Observe(time.Second)
+
+	assert.Equal(t, `{"count": 4, "sum": 1006500000, "buckets": {"1ms": 2, "10ms": 3}}`, histogram.String())
+	assert.True(t, json.Valid([]byte(histogram.String())))
+}
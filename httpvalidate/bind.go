@@ -0,0 +1,342 @@
+package httpvalidate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/gogo-gadget/validator"
+	"github.com/gogo-gadget/validator/internal/convert"
+)
+
+// The struct field tag keys containing the names of the request values that are bound to the fields
+const (
+	QueryTagKey  = "query"
+	FormTagKey   = "form"
+	HeaderTagKey = "header"
+	PathTagKey   = "path"
+)
+
+// TypeValidator is the validator ID of field errors of values that cannot be converted to the type of their field
+const TypeValidator = "type"
+
+// sourceTagKeys contains the tag keys in the order they are looked up if a field has multiple tags
+var sourceTagKeys = []string{QueryTagKey, FormTagKey, HeaderTagKey, PathTagKey}
+
+// Values contains the values of a request that are bound to the fields of a struct by their tags
+type Values struct {
+	// Query is bound to fields with query tag
+	Query url.Values
+	// Form is bound to fields with form tag
+	Form url.Values
+	// Header is bound to fields with header tag, whose names are canonicalized
+	Header http.Header
+	// Path is bound to fields with path tag
+	Path map[string]string
+}
+
+func (values Values) lookup(tagKey string, name string) ([]string, bool) {
+	switch tagKey {
+	case QueryTagKey:
+		raw, ok := values.Query[name]
+		return raw, ok
+	case FormTagKey:
+		raw, ok := values.Form[name]
+		return raw, ok
+	case HeaderTagKey:
+		raw, ok := values.Header[http.CanonicalHeaderKey(name)]
+		return raw, ok
+	case PathTagKey:
+		raw, ok := values.Path[name]
+		return []string{raw}, ok
+	}
+
+	return nil, false
+}
+
+// Bind populates a value of type T from the query, form values, headers and path parameters of the request and validates it
+// with the context of the request. Form values are only read from urlencoded bodies of POST, PUT and PATCH requests.
+// Returns a *Problem listing the conversion and validation errors by the names of their parameters if the request is invalid
+// or any other error e.g. a validator.TagSyntaxError if the validation itself failed.
+func Bind[T any](d *Decoder, r *http.Request) (T, error) {
+	var value T
+
+	if r.Body != nil {
+		r.Body = &limitedReadCloser{limitedReader: limitedReader{reader: r.Body, remaining: d.maxBodyBytes()}, closer: r.Body}
+	}
+
+	err := r.ParseForm()
+	if errors.Is(err, errBodyTooLarge) {
+		return value, NewProblem(http.StatusRequestEntityTooLarge, fmt.Sprintf("request body must not be larger than %v bytes", d.maxBodyBytes()))
+	}
+	if err != nil {
+		return value, NewProblem(http.StatusBadRequest, fmt.Sprintf("request contains invalid form values: %v", err))
+	}
+
+	values := Values{
+		Query:  r.URL.Query(),
+		Form:   r.PostForm,
+		Header: r.Header,
+	}
+	if d.PathParams != nil {
+		values.Path = d.PathParams(r)
+	}
+
+	b, err := d.bind(r.Context(), &value, values)
+	if err == nil {
+		return value, nil
+	}
+
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return value, err
+	}
+
+	problem := NewProblem(http.StatusBadRequest, "request contains invalid parameters")
+	for _, fieldErr := range errs {
+		problem.InvalidParams = append(problem.InvalidParams, InvalidParam{
+			Name:      b.paramName(fieldErr.Path),
+			Reason:    fieldErr.Error(),
+			Validator: fieldErr.Validator,
+		})
+	}
+
+	return value, problem
+}
+
+// BindValues populates the struct the provided pointer points to from the values by the query, form, header and path tags of its fields
+// and validates it. Values that cannot be converted to the type of their field are reported as field errors with the validator ID TypeValidator,
+// whose messages are translated by the locale of the context.
+// Returns ValidationErrors containing the conversion errors followed by the validation errors of all other fields, any other error
+// e.g. a validator.TagSyntaxError or nil if all values have been bound and the validation succeeded.
+func (d *Decoder) BindValues(ctx context.Context, ptr interface{}, values Values) error {
+	_, err := d.bind(ctx, ptr, values)
+	return err
+}
+
+func (d *Decoder) bind(ctx context.Context, ptr interface{}, values Values) (*binder, error) {
+	ptrValue := reflect.ValueOf(ptr)
+	if ptrValue.Kind() != reflect.Ptr || ptrValue.IsNil() {
+		return nil, fmt.Errorf("binding requires a non-nil pointer to a struct but got %T", ptr)
+	}
+
+	structValue := ptrValue.Elem()
+	for structValue.Kind() == reflect.Ptr {
+		if structValue.IsNil() {
+			structValue.Set(reflect.New(structValue.Type().Elem()))
+		}
+		structValue = structValue.Elem()
+	}
+
+	if structValue.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("binding requires a non-nil pointer to a struct but got %T", ptr)
+	}
+
+	b := &binder{values: values, names: map[string]string{}}
+	b.bindStruct(structValue, "")
+	if b.err != nil {
+		return b, b.err
+	}
+
+	v := d.validator()
+	errs := b.errs
+	if locale, ok := validator.LocaleFromContext(ctx); ok && len(errs) > 0 {
+		errs = v.Translate(errs, locale).(validator.ValidationErrors)
+	}
+
+	err := v.ValidateAll(ctx, ptr)
+	var validationErrs validator.ValidationErrors
+	if err != nil && !errors.As(err, &validationErrs) {
+		return b, err
+	}
+
+	for _, fieldErr := range validationErrs {
+		// fields whose values cannot be converted are only reported once
+		if !b.failed(fieldErr.Path) {
+			errs = append(errs, fieldErr)
+		}
+	}
+
+	if len(errs) == 0 {
+		return b, nil
+	}
+
+	return b, errs
+}
+
+// binder populates the fields of a struct from the values of a request
+type binder struct {
+	values Values
+	// names contains the names of the request values by the paths of the fields they are bound to
+	names map[string]string
+	errs  validator.ValidationErrors
+	err   error
+}
+
+// bindStruct binds the fields of a struct and its nested structs.
+// Returns true if any value has been bound.
+func (b *binder) bindStruct(structValue reflect.Value, path string) bool {
+	bound := false
+
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField() && b.err == nil; i++ {
+		structField := structType.Field(i)
+		fieldValue := structValue.Field(i)
+		fieldPath := structField.Name
+		if path != "" {
+			fieldPath = path + "." + structField.Name
+		}
+
+		tagKey, name, ok := sourceTag(structField)
+		if !ok {
+			bound = b.bindNested(fieldValue, fieldPath) || bound
+			continue
+		}
+
+		if !fieldValue.CanSet() {
+			continue
+		}
+
+		if !convert.IsSupported(structField.Type) {
+			b.err = fmt.Errorf("field %v: binding of type %v is not supported", fieldPath, structField.Type)
+			return bound
+		}
+
+		b.names[fieldPath] = name
+		raw, ok := b.values.lookup(tagKey, name)
+		if !ok {
+			continue
+		}
+
+		bound = true
+		err := convert.SetValues(fieldValue, raw)
+		if err != nil {
+			b.errs = append(b.errs, conversionError(structField, fieldPath, err))
+		}
+	}
+
+	return bound
+}
+
+// bindNested binds the fields of an untagged field of kind struct or pointer to struct.
+// Nil pointers are only set if any value has been bound to the nested struct.
+func (b *binder) bindNested(fieldValue reflect.Value, path string) bool {
+	fieldType := fieldValue.Type()
+	if convert.IsScalar(fieldType) {
+		return false
+	}
+
+	switch {
+	case fieldType.Kind() == reflect.Struct:
+		return b.bindStruct(fieldValue, path)
+	case fieldType.Kind() == reflect.Ptr && fieldType.Elem().Kind() == reflect.Struct && !convert.IsScalar(fieldType.Elem()):
+		if !fieldValue.IsNil() {
+			return b.bindStruct(fieldValue.Elem(), path)
+		}
+
+		nested := reflect.New(fieldType.Elem())
+		if !b.bindStruct(nested.Elem(), path) {
+			return false
+		}
+		if fieldValue.CanSet() {
+			fieldValue.Set(nested)
+		}
+		return true
+	}
+
+	return false
+}
+
+// failed returns whether the value of the field of the path could not be converted
+func (b *binder) failed(path string) bool {
+	for _, fieldErr := range b.errs {
+		if fieldErr.Path == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+// paramName returns the name of the request value that has been bound to the field of the path or a parent of the field
+// e.g. tags[1] for Tags[1] if Tags has been bound to the query parameter tags. Returns the path if no value has been bound.
+func (b *binder) paramName(path string) string {
+	if b == nil {
+		return path
+	}
+
+	for prefix := path; prefix != ""; prefix = parentPath(prefix) {
+		if name, ok := b.names[prefix]; ok {
+			return name + path[len(prefix):]
+		}
+	}
+
+	return path
+}
+
+// parentPath removes the last field name, index or key of a field path
+func parentPath(path string) string {
+	segments := splitPath(path)
+	if len(segments) == 0 {
+		return ""
+	}
+
+	last := segments[len(segments)-1]
+	if last.index {
+		return path[:len(path)-len(last.name)-2]
+	}
+
+	return strings.TrimSuffix(path[:len(path)-len(last.name)], ".")
+}
+
+// sourceTag returns the tag key and name of the request value a field is bound to
+func sourceTag(structField reflect.StructField) (string, string, bool) {
+	for _, tagKey := range sourceTagKeys {
+		name, ok := structField.Tag.Lookup(tagKey)
+		if !ok || name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = structField.Name
+		}
+
+		return tagKey, name, true
+	}
+
+	return "", "", false
+}
+
+// conversionError creates the field error of a value of a request that cannot be converted to the type of its field
+func conversionError(structField reflect.StructField, path string, err error) *validator.FieldError {
+	label := structField.Name
+	if tagLabel, ok := structField.Tag.Lookup(validator.LabelTagKey); ok {
+		label = tagLabel
+	}
+
+	var convertErr *convert.Error
+	errors.As(err, &convertErr)
+	convertErr.Path = path
+
+	fieldErr := validator.NewFieldError(path, label, TypeValidator, convertErr)
+	fieldErr.Params = []string{convertErr.Type.String()}
+	fieldErr.Value = convertErr.Value
+	fieldErr.Kind = convertErr.Type.Kind()
+
+	return fieldErr
+}
+
+// limitedReadCloser limits the size of a request body
+type limitedReadCloser struct {
+	limitedReader
+	closer io.Closer
+}
+
+func (l *limitedReadCloser) Close() error {
+	return l.closer.Close()
+}
@@ -0,0 +1,169 @@
+package httpvalidate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator"
+)
+
+type pagination struct {
+	Page    int `query:"page" validator:"min(1)"`
+	PerPage int `query:"per_page" validator:"max(100)"`
+}
+
+type search struct {
+	pagination
+	Query     string        `query:"q" validator:"required"`
+	Tags      []string      `query:"tag" validator:"dive(len(2))"`
+	Since     *time.Time    `query:"since"`
+	Timeout   time.Duration `query:"timeout"`
+	Debug     bool          `query:"debug"`
+	RequestID string        `header:"X-Request-Id" validator:"len(4)"`
+	Tenant    string        `path:"tenant" validator:"len(3)"`
+	Filter    *filter
+	Ignored   string `query:"-"`
+}
+
+type filter struct {
+	Min float64 `query:"min" label:"Minimum"`
+}
+
+func TestDecoder_BindValues(t *testing.T) {
+	decoder := NewDecoder(validator.NewValidator())
+
+	var s search
+	err := decoder.BindValues(context.Background(), &s, Values{
+		Query: url.Values{
+			"page": {"2"}, "per_page": {"50"}, "q": {"gopher"}, "tag": {"ab", "cd"}, "since": {"2020-01-02T03:04:05Z"},
+			"timeout": {"1m30s"}, "debug": {"true"}, "min": {"0.5"}, "Ignored": {"x"},
+		},
+		Header: http.Header{"X-Request-Id": {"abcd"}},
+		Path:   map[string]string{"tenant": "acm"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, s.Page)
+	assert.Equal(t, 50, s.PerPage)
+	assert.Equal(t, "gopher", s.Query)
+	assert.Equal(t, []string{"ab", "cd"}, s.Tags)
+	assert.Equal(t, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), *s.Since)
+	assert.Equal(t, 90*time.Second, s.Timeout)
+	assert.True(t, s.Debug)
+	assert.Equal(t, "abcd", s.RequestID)
+	assert.Equal(t, "acm", s.Tenant)
+	assert.Equal(t, 0.5, s.Filter.Min)
+	assert.Empty(t, s.Ignored)
+
+	s = search{}
+	err = decoder.BindValues(context.Background(), &s, Values{Query: url.Values{"page": {"1"}, "q": {"gopher"}}, Header: http.Header{"X-Request-Id": {"abcd"}}, Path: map[string]string{"tenant": "acm"}})
+	assert.NoError(t, err)
+	assert.Nil(t, s.Filter)
+	assert.Nil(t, s.Since)
+}
+
+func TestDecoder_BindValues_combinesConversionAndValidationErrors(t *testing.T) {
+	decoder := NewDecoder(validator.NewValidator())
+
+	var s search
+	err := decoder.BindValues(context.Background(), &s, Values{
+		Query:  url.Values{"page": {"first"}, "per_page": {"500"}, "tag": {"ab", "c"}, "min": {"low"}},
+		Header: http.Header{"X-Request-Id": {"abcd"}},
+		Path:   map[string]string{"tenant": "acm"},
+	})
+
+	var errs validator.ValidationErrors
+	if assert.True(t, errors.As(err, &errs)) && assert.Len(t, errs, 5) {
+		assert.Equal(t, "pagination.Page", errs[0].Path)
+		assert.Equal(t, TypeValidator, errs[0].Validator)
+		assert.Equal(t, "first", errs[0].Value)
+		assert.EqualError(t, errs[0], `type field pagination.Page has value "first", but should be a valid int`)
+		assert.Equal(t, "Filter.Min", errs[1].Path)
+		assert.Equal(t, "Minimum", errs[1].Label)
+
+		assert.Equal(t, "pagination.PerPage", errs[2].Path)
+		assert.Equal(t, "Query", errs[3].Path)
+		assert.Equal(t, "Tags[1]", errs[4].Path)
+	}
+
+	s = search{}
+	err = decoder.BindValues(validator.WithLocale(context.Background(), "de"), &s, Values{
+		Query: url.Values{"page": {"first"}, "q": {"gopher"}}, Header: http.Header{"X-Request-Id": {"abcd"}}, Path: map[string]string{"tenant": "acm"},
+	})
+	assert.EqualError(t, err, "Page muss ein gültiger Wert vom Typ int sein")
+}
+
+func TestDecoder_BindValues_unsupported(t *testing.T) {
+	decoder := NewDecoder(nil)
+
+	err := decoder.BindValues(context.Background(), search{}, Values{})
+	assert.EqualError(t, err, "binding requires a non-nil pointer to a struct but got httpvalidate.search")
+
+	type unsupported struct {
+		Values map[string]string `query:"values"`
+	}
+	err = decoder.BindValues(context.Background(), &unsupported{}, Values{})
+	assert.EqualError(t, err, "field Values: binding of type map[string]string is not supported")
+}
+
+func TestBind(t *testing.T) {
+	decoder := NewDecoder(validator.NewValidator())
+	decoder.PathParams = func(r *http.Request) map[string]string {
+		return map[string]string{"tenant": strings.TrimPrefix(r.URL.Path, "/tenants/")}
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/tenants/acm?page=1&q=gopher", nil)
+	r.Header.Set("X-Request-Id", "abcd")
+	s, err := Bind[search](decoder, r)
+	assert.NoError(t, err)
+	assert.Equal(t, "acm", s.Tenant)
+
+	r = httptest.NewRequest(http.MethodGet, "/tenants/acme?page=0&tag=ab&tag=c&timeout=soon", nil)
+	_, err = Bind[*search](decoder, r)
+
+	var problem *Problem
+	if assert.True(t, errors.As(err, &problem)) {
+		assert.Equal(t, http.StatusBadRequest, problem.Status)
+
+		var names []string
+		for _, param := range problem.InvalidParams {
+			names = append(names, param.Name)
+		}
+		assert.Equal(t, []string{"timeout", "page", "q", "tag[1]", "X-Request-Id", "tenant"}, names)
+		assert.Equal(t, "type", problem.InvalidParams[0].Validator)
+	}
+}
+
+type login struct {
+	User     string `form:"user" validator:"required"`
+	Password string `form:"password" validator:"min(8)"`
+}
+
+func TestBind_form(t *testing.T) {
+	decoder := NewDecoder(validator.NewValidator())
+	decoder.MaxBodyBytes = 64
+
+	r := httptest.NewRequest(http.MethodPost, "/login?user=ignored", strings.NewReader("user=jane&password=secret"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	_, err := Bind[login](decoder, r)
+
+	var problem *Problem
+	if assert.True(t, errors.As(err, &problem)) && assert.Len(t, problem.InvalidParams, 1) {
+		assert.Equal(t, "password", problem.InvalidParams[0].Name)
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/login", strings.NewReader("user="+strings.Repeat("a", 64)))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	_, err = Bind[login](decoder, r)
+	if assert.True(t, errors.As(err, &problem)) {
+		assert.Equal(t, http.StatusRequestEntityTooLarge, problem.Status)
+	}
+}
@@ -0,0 +1,201 @@
+// Package httpvalidate decodes and validates JSON request bodies and reports invalid requests as problem details (RFC 7807).
+// Usage:
+//
+//	decoder := httpvalidate.NewDecoder(validator.NewValidator())
+//	http.Handle("/users", httpvalidate.Handler(decoder, func(w http.ResponseWriter, r *http.Request, user User) {
+//		...
+//	}))
+package httpvalidate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gogo-gadget/validator"
+)
+
+// DefaultMaxBodyBytes is the maximum size of a request body if the decoder has no limit configured
+const DefaultMaxBodyBytes = 1 << 20
+
+// Decoder decodes JSON request bodies and validates the decoded values
+type Decoder struct {
+	// Validator validates the decoded values. If nil, a validator with the default custom validators is used.
+	Validator *validator.Validator
+	// MaxBodyBytes is the maximum size of a request body. If zero or negative, DefaultMaxBodyBytes is used.
+	MaxBodyBytes int64
+	// AllowUnknownFields accepts JSON objects containing fields that do not match any field of the decoded struct
+	AllowUnknownFields bool
+	// PathParams returns the path parameters of a request by their names e.g. by a router.
+	// If nil, no values are bound to fields with path tag by Bind.
+	PathParams func(r *http.Request) map[string]string
+}
+
+// NewDecoder creates a decoder that validates by the provided validator, limits request bodies to DefaultMaxBodyBytes
+// and rejects unknown fields.
+func NewDecoder(v *validator.Validator) *Decoder {
+	return &Decoder{
+		Validator:    v,
+		MaxBodyBytes: DefaultMaxBodyBytes,
+	}
+}
+
+// Decode decodes the JSON body of the request into a value of type T and validates it with the context of the request.
+// All invalid fields are reported, not only the first one.
+// Returns a *Problem if the request is invalid or any other error e.g. a validator.TagSyntaxError if the validation itself failed.
+func Decode[T any](d *Decoder, r *http.Request) (T, error) {
+	var value T
+
+	problem := d.decode(r, &value)
+	if problem != nil {
+		return value, problem
+	}
+
+	err := d.validator().ValidateAll(r.Context(), &value)
+	if err == nil {
+		return value, nil
+	}
+
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return value, err
+	}
+
+	return value, validationProblem(value, errs)
+}
+
+// Handler returns a handler decoding and validating the JSON body of every request before calling the provided function.
+// Invalid requests are answered by WriteProblem and do not reach the function.
+func Handler[T any](d *Decoder, handle func(w http.ResponseWriter, r *http.Request, value T)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value, err := Decode[T](d, r)
+		if err != nil {
+			WriteProblem(w, err)
+			return
+		}
+
+		handle(w, r, value)
+	})
+}
+
+func (d *Decoder) validator() *validator.Validator {
+	if d.Validator == nil {
+		return validator.NewValidator()
+	}
+
+	return d.Validator
+}
+
+func (d *Decoder) maxBodyBytes() int64 {
+	if d.MaxBodyBytes <= 0 {
+		return DefaultMaxBodyBytes
+	}
+
+	return d.MaxBodyBytes
+}
+
+// decode decodes the body of the request into the value the provided pointer points to
+func (d *Decoder) decode(r *http.Request, ptr interface{}) *Problem {
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+			return NewProblem(http.StatusUnsupportedMediaType, fmt.Sprintf("content type %v is not supported, expected application/json", contentType))
+		}
+	}
+
+	if r.Body == nil || r.Body == http.NoBody {
+		return NewProblem(http.StatusBadRequest, "request body is empty")
+	}
+
+	maxBytes := d.maxBodyBytes()
+	decoder := json.NewDecoder(&limitedReader{reader: r.Body, remaining: maxBytes})
+	if !d.AllowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+
+	err := decoder.Decode(ptr)
+	if err == nil && decoder.More() {
+		err = errors.New("request body must contain a single JSON value")
+	}
+	if errors.Is(err, errBodyTooLarge) {
+		return NewProblem(http.StatusRequestEntityTooLarge, fmt.Sprintf("request body must not be larger than %v bytes", maxBytes))
+	}
+	if err != nil {
+		return decodeProblem(err)
+	}
+
+	return nil
+}
+
+var errBodyTooLarge = errors.New("request body too large")
+
+// limitedReader reads from a reader until the remaining bytes are exceeded and fails with errBodyTooLarge afterwards
+type limitedReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, errBodyTooLarge
+	}
+
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+
+	n, err := l.reader.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return 0, errBodyTooLarge
+	}
+
+	return n, err
+}
+
+// decodeProblem describes an error of the JSON decoder
+func decodeProblem(err error) *Problem {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return NewProblem(http.StatusBadRequest, "request body is empty")
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return NewProblem(http.StatusBadRequest, "request body contains incomplete JSON")
+	case errors.As(err, &syntaxErr):
+		return NewProblem(http.StatusBadRequest, fmt.Sprintf("request body contains invalid JSON at offset %v", syntaxErr.Offset))
+	case errors.As(err, &typeErr):
+		problem := NewProblem(http.StatusBadRequest, "request body contains a value of an invalid type")
+		problem.InvalidParams = []InvalidParam{{
+			Name:   fieldPointer(typeErr.Field),
+			Reason: fmt.Sprintf("must be of type %v, not %v", typeErr.Type, typeErr.Value),
+		}}
+		return problem
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// the decoder does not provide a typed error for unknown fields
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return NewProblem(http.StatusBadRequest, fmt.Sprintf("request body contains unknown field %v", field))
+	}
+
+	return NewProblem(http.StatusBadRequest, err.Error())
+}
+
+// fieldPointer converts the dotted field path of the JSON decoder e.g. address.city to a JSON pointer
+func fieldPointer(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	var pointer strings.Builder
+	for _, token := range strings.Split(path, ".") {
+		pointer.WriteString("/")
+		pointer.WriteString(escapePointerToken(token))
+	}
+
+	return pointer.String()
+}
@@ -0,0 +1,183 @@
+package httpvalidate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator"
+)
+
+type base struct {
+	ID string `json:"id" validator:"len(3)"`
+}
+
+type user struct {
+	base
+	Name    string            `json:"name" validator:"required"`
+	Email   string            `json:"email,omitempty" validator:"email || len(0)"`
+	Tags    []string          `json:"tags" validator:"dive(len(2))"`
+	Labels  map[string]string `json:"labels" validator:"dive(required)"`
+	Address *address          `json:"address"`
+}
+
+type address struct {
+	City string `json:"city" validator:"required"`
+}
+
+func newHandler(decoder *Decoder) http.Handler {
+	return Handler(decoder, func(w http.ResponseWriter, r *http.Request, u user) {
+		_, _ = fmt.Fprint(w, u.Name)
+	})
+}
+
+func serve(handler http.Handler, contentType string, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	return w
+}
+
+func decodeProblemResponse(t *testing.T, w *httptest.ResponseRecorder) Problem {
+	assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+
+	var problem Problem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+
+	return problem
+}
+
+func TestHandler_valid(t *testing.T) {
+	handler := newHandler(NewDecoder(validator.NewValidator()))
+
+	w := serve(handler, "application/json", `{"id":"abc","name":"Jane","email":"jane@test.com","tags":["ab"],"address":{"city":"Berlin"}}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Jane", w.Body.String())
+}
+
+func TestHandler_listsEveryInvalidField(t *testing.T) {
+	handler := newHandler(NewDecoder(validator.NewValidator()))
+
+	w := serve(handler, "application/json; charset=utf-8", `{"id":"ab","email":"invalid","tags":["ab","c"],"labels":{"a/b":""},"address":{}}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	problem := decodeProblemResponse(t, w)
+	assert.Equal(t, "about:blank", problem.Type)
+	assert.Equal(t, "Bad Request", problem.Title)
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+
+	var names []string
+	for _, param := range problem.InvalidParams {
+		names = append(names, param.Name)
+		assert.NotEmpty(t, param.Reason)
+	}
+	assert.Equal(t, []string{"/id", "/name", "/email", "/tags/1", "/labels/a~1b", "/address/city"}, names)
+	assert.Equal(t, "required", problem.InvalidParams[1].Validator)
+}
+
+func TestHandler_translatesByLocaleOfContext(t *testing.T) {
+	handler := newHandler(NewDecoder(validator.NewValidator()))
+	localized := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r.WithContext(validator.WithLocale(r.Context(), "de")))
+	})
+
+	w := serve(localized, "", `{"id":"abc","address":{"city":"Berlin"}}`)
+
+	problem := decodeProblemResponse(t, w)
+	if assert.Len(t, problem.InvalidParams, 1) {
+		assert.Equal(t, InvalidParam{Name: "/name", Reason: "Name ist erforderlich", Validator: "required"}, problem.InvalidParams[0])
+	}
+}
+
+func TestHandler_decodeErrors(t *testing.T) {
+	decoder := NewDecoder(validator.NewValidator())
+	decoder.MaxBodyBytes = 64
+	handler := newHandler(decoder)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+		detail      string
+	}{
+		{"empty", "application/json", "", http.StatusBadRequest, "request body is empty"},
+		{"invalid json", "application/json", `{"name":}`, http.StatusBadRequest, "request body contains invalid JSON at offset 9"},
+		{"incomplete json", "application/json", `{"name":"Jane"`, http.StatusBadRequest, "request body contains incomplete JSON"},
+		{"multiple values", "application/json", `{"name":"Jane"} {}`, http.StatusBadRequest, "request body must contain a single JSON value"},
+		{"unknown field", "application/json", `{"nickname":"Jane"}`, http.StatusBadRequest, "request body contains unknown field nickname"},
+		{"invalid type", "application/json", `{"name":1}`, http.StatusBadRequest, "request body contains a value of an invalid type"},
+		{"too large", "application/json", `{"name":"` + strings.Repeat("a", 64) + `"}`, http.StatusRequestEntityTooLarge, "request body must not be larger than 64 bytes"},
+		{"content type", "text/plain", `{"name":"Jane"}`, http.StatusUnsupportedMediaType, "content type text/plain is not supported, expected application/json"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := serve(handler, test.contentType, test.body)
+
+			assert.Equal(t, test.status, w.Code)
+			problem := decodeProblemResponse(t, w)
+			assert.Equal(t, test.status, problem.Status)
+			assert.Equal(t, test.detail, problem.Detail)
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	decoder := NewDecoder(nil)
+
+	r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":1}`))
+	_, err := Decode[user](decoder, r)
+	var problem *Problem
+	if assert.True(t, errors.As(err, &problem)) {
+		assert.Equal(t, []InvalidParam{{Name: "/name", Reason: "must be of type string, not number"}}, problem.InvalidParams)
+	}
+
+	decoder.AllowUnknownFields = true
+	r = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"id":"abc","name":"Jane","nickname":"J","address":{"city":"Berlin"}}`))
+	u, err := Decode[*user](decoder, r)
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane", u.Name)
+
+	type invalidTag struct {
+		Name string `validator:"len("`
+	}
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+	_, err = Decode[invalidTag](decoder, r)
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &problem))
+
+	w := httptest.NewRecorder()
+	WriteProblem(w, err)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "", decodeProblemResponse(t, w).Detail)
+}
+
+func TestPointer(t *testing.T) {
+	tests := map[string]string{
+		"Name":             "/name",
+		"base.ID":          "/id",
+		"Address.City":     "/address/city",
+		"Tags[0]":          "/tags/0",
+		"Labels[a.b]":      "/labels/a.b",
+		"Labels[a~b]":      "/labels/a~0b",
+		"Labels[[x]]":      "/labels/[x]",
+		"Unknown.Field[1]": "/Unknown/Field/1",
+	}
+
+	for path, expected := range tests {
+		assert.Equal(t, expected, Pointer(&user{}, path), path)
+	}
+}
@@ -0,0 +1,125 @@
+package httpvalidate
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Pointer converts the path of a validator.FieldError of a value e.g. Address.City or Tags[0] to a JSON pointer
+// e.g. /address/city or /tags/0 using the names of the json tags of the fields.
+// Fields of embedded structs without json name are promoted like by the JSON encoder.
+// Parts of the path that cannot be found in the type of the value keep their names.
+func Pointer(value interface{}, path string) string {
+	rType := reflect.TypeOf(value)
+
+	var pointer strings.Builder
+	for _, segment := range splitPath(path) {
+		rType = deref(rType)
+
+		if segment.index {
+			pointer.WriteString("/")
+			pointer.WriteString(escapePointerToken(segment.name))
+			if rType != nil && (rType.Kind() == reflect.Slice || rType.Kind() == reflect.Array || rType.Kind() == reflect.Map) {
+				rType = rType.Elem()
+			} else {
+				rType = nil
+			}
+			continue
+		}
+
+		if rType == nil || rType.Kind() != reflect.Struct {
+			pointer.WriteString("/")
+			pointer.WriteString(escapePointerToken(segment.name))
+			rType = nil
+			continue
+		}
+
+		structField, ok := rType.FieldByName(segment.name)
+		if !ok {
+			pointer.WriteString("/")
+			pointer.WriteString(escapePointerToken(segment.name))
+			rType = nil
+			continue
+		}
+		rType = structField.Type
+
+		name, promoted := jsonName(structField)
+		if promoted {
+			continue
+		}
+
+		pointer.WriteString("/")
+		pointer.WriteString(escapePointerToken(name))
+	}
+
+	return pointer.String()
+}
+
+// pathSegment is a field name or an index or key of a field path
+type pathSegment struct {
+	name  string
+	index bool
+}
+
+// splitPath splits a field path e.g. Address.Tags[0] into field names and indices or keys
+func splitPath(path string) []pathSegment {
+	var segments []pathSegment
+	for len(path) > 0 {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+		case '[':
+			end := indexEnd(path)
+			segments = append(segments, pathSegment{name: path[1:end], index: true})
+			path = path[end+1:]
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			segments = append(segments, pathSegment{name: path[:end]})
+			path = path[end:]
+		}
+	}
+
+	return segments
+}
+
+// indexEnd returns the position of the bracket closing the index at the start of the path.
+// Keys may contain brackets, so the closing bracket is the first one that is followed by another segment or the end of the path.
+func indexEnd(path string) int {
+	for i := 1; i < len(path); i++ {
+		if path[i] == ']' && (i == len(path)-1 || path[i+1] == '.' || path[i+1] == '[') {
+			return i
+		}
+	}
+
+	return len(path) - 1
+}
+
+// jsonName returns the name of a field in JSON and whether its fields are promoted to its parent
+func jsonName(structField reflect.StructField) (string, bool) {
+	name := strings.Split(structField.Tag.Get("json"), ",")[0]
+	if name != "" && name != "-" {
+		return name, false
+	}
+
+	if structField.Anonymous && deref(structField.Type).Kind() == reflect.Struct {
+		return "", true
+	}
+
+	return structField.Name, false
+}
+
+func deref(rType reflect.Type) reflect.Type {
+	for rType != nil && rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+
+	return rType
+}
+
+// escapePointerToken escapes a reference token of a JSON pointer as defined by RFC 6901
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,82 @@
+package httpvalidate
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gogo-gadget/validator"
+)
+
+// ProblemContentType is the content type of problem details responses
+const ProblemContentType = "application/problem+json"
+
+// Problem contains the details of an invalid request as defined by RFC 7807
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// InvalidParams contains every invalid field of the request body
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// InvalidParam describes an invalid field of a request body
+type InvalidParam struct {
+	// Name is the JSON pointer of the field e.g. /address/city
+	Name string `json:"name"`
+	// Reason is the message of the validation error
+	Reason string `json:"reason"`
+	// Validator is the ID of the failed custom validator or the kind of the failed operator
+	Validator string `json:"validator,omitempty"`
+}
+
+// NewProblem creates a problem of the type about:blank whose title is the status text of the provided status code
+func NewProblem(status int, detail string) *Problem {
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// Error returns the detail of the problem or its title
+// Implements error interface
+func (p *Problem) Error() string {
+	if p.Detail == "" {
+		return p.Title
+	}
+
+	return p.Title + ": " + p.Detail
+}
+
+// WriteProblem writes an error as application/problem+json response.
+// A *Problem is written as is, all other errors are written as internal server error without exposing their messages.
+func WriteProblem(w http.ResponseWriter, err error) {
+	var problem *Problem
+	if !errors.As(err, &problem) {
+		problem = NewProblem(http.StatusInternalServerError, "")
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// validationProblem lists the errors of the validation of a decoded value by the JSON pointers of their fields
+func validationProblem(value interface{}, errs validator.ValidationErrors) *Problem {
+	problem := NewProblem(http.StatusBadRequest, "request body contains invalid fields")
+	for _, fieldErr := range errs {
+		problem.InvalidParams = append(problem.InvalidParams, InvalidParam{
+			Name:      Pointer(value, fieldErr.Path),
+			Reason:    fieldErr.Error(),
+			Validator: fieldErr.Validator,
+		})
+	}
+
+	return problem
+}
@@ -0,0 +1,213 @@
+// Package convert converts strings e.g. request parameters, environment variables or default values to the types of struct fields
+package convert
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Error is returned if a string cannot be converted to the type of a field
+type Error struct {
+	// Path is the full name of the field, which is set by the caller e.g. Address.Street
+	Path string
+	// Value is the string that cannot be converted
+	Value string
+	// Type is the type the string should have been converted to
+	Type reflect.Type
+	// Err is the error of the conversion
+	Err error
+}
+
+// Error returns the error message string
+// Implements error interface
+func (err *Error) Error() string {
+	return fmt.Sprintf("type field %v has value %q, but should be a valid %v", err.Path, err.Value, err.Type)
+}
+
+// Unwrap returns the error of the conversion
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// IsScalar returns whether a single string can be converted to the type: strings, booleans, numbers, durations and
+// types implementing encoding.TextUnmarshaler e.g. time.Time
+func IsScalar(rType reflect.Type) bool {
+	if rType == durationType || reflect.PtrTo(rType).Implements(textUnmarshalerType) {
+		return true
+	}
+
+	switch rType.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+// IsSupported returns whether strings can be converted to the type: scalars, pointers to scalars and slices of both
+func IsSupported(rType reflect.Type) bool {
+	switch {
+	case IsScalar(rType):
+		return true
+	case rType.Kind() == reflect.Ptr:
+		return IsScalar(rType.Elem())
+	case rType.Kind() == reflect.Slice:
+		return IsScalar(rType.Elem()) || (rType.Elem().Kind() == reflect.Ptr && IsScalar(rType.Elem().Elem()))
+	}
+
+	return false
+}
+
+// SetValues converts the strings to the type of the settable value. Slices are set to all converted strings,
+// all other values to the first string. Returns an *Error if a string cannot be converted.
+func SetValues(value reflect.Value, raw []string) error {
+	if value.Kind() == reflect.Slice && !IsScalar(value.Type()) {
+		slice := reflect.MakeSlice(value.Type(), len(raw), len(raw))
+		for i, s := range raw {
+			err := SetValue(slice.Index(i), s)
+			if err != nil {
+				return err
+			}
+		}
+
+		value.Set(slice)
+		return nil
+	}
+
+	if len(raw) == 0 {
+		return nil
+	}
+
+	return SetValue(value, raw[0])
+}
+
+// Split splits a string into the strings of the elements of a slice type by the separator. An empty string is an
+// empty slice. Returns the string itself for all other types.
+func Split(rType reflect.Type, s string, separator string) []string {
+	if rType.Kind() != reflect.Slice || IsScalar(rType) {
+		return []string{s}
+	}
+
+	if s == "" {
+		return []string{}
+	}
+
+	return strings.Split(s, separator)
+}
+
+// FillStruct fills a value of kind struct or pointer to struct by the fill function, which returns whether it has set
+// any field. A nil pointer is only set to a new struct if allocate is true and the new struct has been filled.
+// Returns false for all other values and for types implementing encoding.TextUnmarshaler e.g. time.Time.
+func FillStruct(value reflect.Value, allocate bool, fill func(structValue reflect.Value) (bool, error)) (bool, error) {
+	rType := value.Type()
+	if IsScalar(rType) {
+		return false, nil
+	}
+
+	switch {
+	case rType.Kind() == reflect.Struct:
+		return fill(value)
+	case rType.Kind() == reflect.Ptr && rType.Elem().Kind() == reflect.Struct && !IsScalar(rType.Elem()):
+		if !value.IsNil() {
+			return fill(value.Elem())
+		}
+
+		if !allocate || !value.CanSet() {
+			return false, nil
+		}
+
+		nested := reflect.New(rType.Elem())
+		filled, err := fill(nested.Elem())
+		if err != nil || !filled {
+			return false, err
+		}
+
+		value.Set(nested)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// SetValue converts the string to the type of the settable value. Nil pointers are set to a new converted value.
+// Returns an *Error if the string cannot be converted.
+func SetValue(value reflect.Value, s string) error {
+	if value.Kind() == reflect.Ptr && !IsScalar(value.Type()) {
+		elem := reflect.New(value.Type().Elem())
+		err := SetValue(elem.Elem(), s)
+		if err != nil {
+			return err
+		}
+
+		value.Set(elem)
+		return nil
+	}
+
+	err := convert(value, s)
+	if err != nil {
+		return &Error{Value: s, Type: value.Type(), Err: err}
+	}
+
+	return nil
+}
+
+func convert(value reflect.Value, s string) error {
+	if value.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+
+		value.SetInt(int64(d))
+		return nil
+	}
+
+	if unmarshaler, ok := value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(s))
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(f)
+	default:
+		return fmt.Errorf("conversion of kind %v is not supported", value.Kind())
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package convert
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type values struct {
+	String   string
+	Bool     bool
+	Int8     int8
+	Uint     uint
+	Float    float32
+	Duration time.Duration
+	Time     time.Time
+	Ptr      *int
+	Slice    []*int
+	Map      map[string]string
+}
+
+func TestSetValues(t *testing.T) {
+	var v values
+	rValue := reflect.ValueOf(&v).Elem()
+
+	set := func(field string, raw ...string) error {
+		return SetValues(rValue.FieldByName(field), raw)
+	}
+
+	assert.NoError(t, set("String", "a", "b"))
+	assert.NoError(t, set("Bool", "true"))
+	assert.NoError(t, set("Int8", "-8"))
+	assert.NoError(t, set("Uint", "8"))
+	assert.NoError(t, set("Float", "0.5"))
+	assert.NoError(t, set("Duration", "1h"))
+	assert.NoError(t, set("Time", "2020-01-02T03:04:05Z"))
+	assert.NoError(t, set("Ptr", "1"))
+	assert.NoError(t, set("Slice", "1", "2"))
+	assert.NoError(t, set("Bool"))
+
+	one, two := 1, 2
+	assert.Equal(t, values{
+		String:   "a",
+		Bool:     true,
+		Int8:     -8,
+		Uint:     8,
+		Float:    0.5,
+		Duration: time.Hour,
+		Time:     time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Ptr:      &one,
+		Slice:    []*int{&one, &two},
+	}, v)
+
+	err := set("Int8", "128")
+	assert.EqualError(t, err, `type field  has value "128", but should be a valid int8`)
+	var convertErr *Error
+	if assert.True(t, errors.As(err, &convertErr)) {
+		assert.Equal(t, "128", convertErr.Value)
+		assert.ErrorIs(t, err, strconv.ErrRange)
+	}
+
+	assert.Error(t, set("Slice", "1", "x"))
+	assert.Error(t, set("Map", "x"))
+}
+
+func TestSplit(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, Split(reflect.TypeOf([]string{}), "a;b", ";"))
+	assert.Equal(t, []string{}, Split(reflect.TypeOf([]int{}), "", ";"))
+	assert.Equal(t, []string{"a;b"}, Split(reflect.TypeOf(""), "a;b", ";"))
+	assert.Equal(t, []string{""}, Split(reflect.TypeOf(0), "", ";"))
+}
+
+func TestIsSupported(t *testing.T) {
+	rType := reflect.TypeOf(values{})
+	for i := 0; i < rType.NumField(); i++ {
+		field := rType.Field(i)
+		assert.Equal(t, field.Name != "Map", IsSupported(field.Type), field.Name)
+	}
+
+	assert.True(t, IsScalar(reflect.TypeOf(time.Time{})))
+	assert.False(t, IsScalar(reflect.TypeOf([]string{})))
+}
@@ -0,0 +1,15 @@
+// Package gotypes contains helpers for the types of go/types shared by the static analysis of validator tags and the code generator
+package gotypes
+
+import "go/types"
+
+// Deref returns the type a pointer type points to, following pointers to pointers until the type is no pointer
+func Deref(typ types.Type) types.Type {
+	for {
+		ptr, ok := typ.Underlying().(*types.Pointer)
+		if !ok {
+			return typ
+		}
+		typ = ptr.Elem()
+	}
+}
@@ -0,0 +1,485 @@
+// Package lint checks validator tags of Go source code statically without running the validation.
+// Tags are checked against the tag grammar, the registered custom validators and their parameter schemas
+// as well as against the kinds of the tagged fields.
+// Usage:
+//
+//	diagnostics, err := lint.New(validator.NewValidator()).Run("./...")
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gogo-gadget/validator"
+	"github.com/gogo-gadget/validator/internal/gotypes"
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// Diagnostic is a problem found in a validator tag
+type Diagnostic struct {
+	Pos token.Position
+	// Field is the name of the tagged field prefixed by the name of its struct type e.g. User.Name
+	Field   string
+	Message string
+}
+
+// String formats the diagnostic as file:line:column: field: message
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%v: %v: %v", d.Pos, d.Field, d.Message)
+}
+
+// Linter checks the validator tags of Go packages against the configuration of a validator
+type Linter struct {
+	// Validator contains the custom validators, aliases and tag keys the tags are checked against
+	Validator *validator.Validator
+	// Tests enables the linting of test files
+	Tests bool
+	// Ignore contains the ids of custom validators and aliases that are registered at runtime by the linted application
+	// and are therefore not reported as unknown
+	Ignore []string
+
+	fset     *token.FileSet
+	importer types.Importer
+}
+
+// New creates a new linter checking tags against the provided validator
+func New(v *validator.Validator) *Linter {
+	fset := token.NewFileSet()
+
+	return &Linter{
+		Validator: v,
+		fset:      fset,
+		importer:  importer.ForCompiler(fset, "source", nil),
+	}
+}
+
+// Run lints the packages in the provided directories, a directory ending with /... includes all of its subdirectories.
+// The diagnostics are sorted by their position.
+func (l *Linter) Run(patterns ...string) ([]Diagnostic, error) {
+	var diagnostics []Diagnostic
+	for _, pattern := range patterns {
+		dirs := []string{pattern}
+		if strings.HasSuffix(pattern, "/...") {
+			var err error
+			dirs, err = subdirectories(strings.TrimSuffix(pattern, "/..."))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, dir := range dirs {
+			dirDiagnostics, err := l.LintDir(dir)
+			if err != nil {
+				return nil, err
+			}
+			diagnostics = append(diagnostics, dirDiagnostics...)
+		}
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i].Pos, diagnostics[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+
+	return diagnostics, nil
+}
+
+// LintDir lints the package in the provided directory.
+// Directories without Go files do not result in an error.
+func (l *Linter) LintDir(dir string) ([]Diagnostic, error) {
+	buildPkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		var noGoErr *build.NoGoError
+		if errors.As(err, &noGoErr) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	fileSets := [][]string{buildPkg.GoFiles}
+	if l.Tests {
+		fileSets[0] = append(fileSets[0], buildPkg.TestGoFiles...)
+		// external test packages are type checked separately
+		fileSets = append(fileSets, buildPkg.XTestGoFiles)
+	}
+
+	var diagnostics []Diagnostic
+	for _, fileNames := range fileSets {
+		pkgDiagnostics, err := l.lintFiles(dir, fileNames)
+		if err != nil {
+			return nil, err
+		}
+		diagnostics = append(diagnostics, pkgDiagnostics...)
+	}
+
+	return diagnostics, nil
+}
+
+func (l *Linter) lintFiles(dir string, fileNames []string) ([]Diagnostic, error) {
+	if len(fileNames) == 0 {
+		return nil, nil
+	}
+
+	var files []*ast.File
+	for _, name := range fileNames {
+		file, err := parser.ParseFile(l.fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	info := &types.Info{
+		Types: map[ast.Expr]types.TypeAndValue{},
+	}
+	conf := types.Config{
+		Importer: l.importer,
+		// fields of types that cannot be resolved are still checked against the grammar and the custom validators
+		Error: func(err error) {},
+	}
+	pkg, _ := conf.Check(dir, l.fset, files, info)
+
+	var diagnostics []Diagnostic
+	for _, file := range files {
+		typeNames := map[*ast.StructType]string{}
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch n := node.(type) {
+			case *ast.TypeSpec:
+				if structType, ok := n.Type.(*ast.StructType); ok {
+					typeNames[structType] = n.Name.Name
+				}
+			case *ast.StructType:
+				diagnostics = append(diagnostics, l.lintStruct(pkg, info, typeNames[n], n)...)
+			}
+			return true
+		})
+	}
+
+	return diagnostics, nil
+}
+
+func (l *Linter) lintStruct(pkg *types.Package, info *types.Info, typeName string, structType *ast.StructType) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, field := range structType.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil || !l.Validator.HasTagKey(reflect.StructTag(tag)) {
+			continue
+		}
+
+		var messages []string
+		node, err := l.Validator.CompileStructTag(reflect.StructTag(tag))
+		switch {
+		case err != nil:
+			messages = append(messages, err.Error())
+		case node != nil:
+			// empty and ignored tags e.g. validator:"-" compile to no node
+			messages = append(messages, l.unknownValidations(node)...)
+			messages = append(messages, l.invalidFieldRefs(pkg, info.TypeOf(structType), node)...)
+			if fieldType := info.TypeOf(field.Type); fieldType != nil {
+				messages = append(messages, checkKinds(node, fieldType)...)
+			}
+		}
+
+		pos := l.fset.Position(field.Tag.Pos())
+		for _, name := range fieldNames(field) {
+			if typeName != "" {
+				name = fmt.Sprintf("%v.%v", typeName, name)
+			}
+
+			for _, message := range messages {
+				diagnostics = append(diagnostics, Diagnostic{Pos: pos, Field: name, Message: message})
+			}
+		}
+	}
+
+	return diagnostics
+}
+
+// unknownValidations reports sub tags that do not match any custom validator and would therefore be ignored by the validation
+func (l *Linter) unknownValidations(node *validator.Node) []string {
+	var messages []string
+	for _, validation := range node.Validations() {
+		if len(validation.CustomValidators()) > 0 {
+			continue
+		}
+
+		name, _, err := cv.ParseSubTag(validation.SubTag)
+		if err != nil {
+			name = validation.SubTag
+		}
+
+		if l.isIgnored(name) {
+			continue
+		}
+
+		message := fmt.Sprintf("unknown validator %q", name)
+		if suggestion := l.suggest(name); suggestion != "" {
+			message = fmt.Sprintf("%v, did you mean %q?", message, suggestion)
+		}
+		messages = append(messages, message)
+	}
+
+	return messages
+}
+
+// isIgnored returns whether the provided validator id is ignored by the linter
+func (l *Linter) isIgnored(id string) bool {
+	for _, ignored := range l.Ignore {
+		if ignored == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// suggest returns the id of the registered custom validator closest to the provided name or an empty string if none is similar
+func (l *Linter) suggest(name string) string {
+	ids := make([]string, 0, len(l.Validator.CustomValidators))
+	for id := range l.Validator.CustomValidators {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	suggestion, best := "", 3
+	for _, id := range ids {
+		if distance := editDistance(name, id); distance < best {
+			suggestion, best = id, distance
+		}
+	}
+
+	return suggestion
+}
+
+// invalidFieldRefs reports field parameters referencing fields that do not exist on the struct
+func (l *Linter) invalidFieldRefs(pkg *types.Package, structType types.Type, node *validator.Node) []string {
+	if structType == nil {
+		return nil
+	}
+
+	var messages []string
+	for _, validation := range node.Validations() {
+		for _, customValidator := range validation.CustomValidators() {
+			validationCtx, err := customValidator.NewValidationContext(validation.SubTag)
+			if err != nil {
+				continue
+			}
+
+			for _, arg := range validationCtx.Args {
+				ref, ok := arg.Value.(cv.FieldRef)
+				if ok && !hasFieldPath(pkg, structType, ref.Path) {
+					messages = append(messages, fmt.Sprintf("validation %v references unknown field %v", validation.SubTag, ref.Path))
+				}
+			}
+		}
+	}
+
+	return messages
+}
+
+// checkKinds reports custom validators and dive validations that do not support the kind of the field
+func checkKinds(node *validator.Node, fieldType types.Type) []string {
+	kind, ok := kindOf(fieldType)
+	if !ok {
+		// the kind of interfaces is only known at runtime
+		return nil
+	}
+
+	switch node.Kind {
+	case validator.ValidationNode:
+		var messages []string
+		for _, customValidator := range node.CustomValidators() {
+			if customValidator.Config != nil && !customValidator.Config.SupportsKind(kind) {
+				messages = append(messages, fmt.Sprintf("validator %v does not support fields of kind %v", customValidator.ID, kind))
+			}
+		}
+		return messages
+	case validator.DiveNode:
+		var elemType types.Type
+		switch u := gotypes.Deref(fieldType).Underlying().(type) {
+		case *types.Slice:
+			elemType = u.Elem()
+		case *types.Array:
+			elemType = u.Elem()
+		case *types.Map:
+			elemType = u.Elem()
+		default:
+			return []string{fmt.Sprintf("dive validation does not support fields of kind %v", kind)}
+		}
+		return checkKinds(node.Children[0], elemType)
+	}
+
+	var messages []string
+	for _, child := range node.Children {
+		messages = append(messages, checkKinds(child, fieldType)...)
+	}
+
+	return messages
+}
+
+// basicKinds maps the basic types of go/types to their reflect kinds
+var basicKinds = map[types.BasicKind]reflect.Kind{
+	types.Bool:          reflect.Bool,
+	types.Int:           reflect.Int,
+	types.Int8:          reflect.Int8,
+	types.Int16:         reflect.Int16,
+	types.Int32:         reflect.Int32,
+	types.Int64:         reflect.Int64,
+	types.Uint:          reflect.Uint,
+	types.Uint8:         reflect.Uint8,
+	types.Uint16:        reflect.Uint16,
+	types.Uint32:        reflect.Uint32,
+	types.Uint64:        reflect.Uint64,
+	types.Uintptr:       reflect.Uintptr,
+	types.Float32:       reflect.Float32,
+	types.Float64:       reflect.Float64,
+	types.Complex64:     reflect.Complex64,
+	types.Complex128:    reflect.Complex128,
+	types.String:        reflect.String,
+	types.UnsafePointer: reflect.UnsafePointer,
+}
+
+// kindOf returns the reflect kind of the dereferenced type.
+// Returns false for interfaces and types that could not be resolved.
+func kindOf(typ types.Type) (reflect.Kind, bool) {
+	switch u := gotypes.Deref(typ).Underlying().(type) {
+	case *types.Basic:
+		kind, ok := basicKinds[u.Kind()]
+		return kind, ok
+	case *types.Slice:
+		return reflect.Slice, true
+	case *types.Array:
+		return reflect.Array, true
+	case *types.Map:
+		return reflect.Map, true
+	case *types.Struct:
+		return reflect.Struct, true
+	case *types.Chan:
+		return reflect.Chan, true
+	case *types.Signature:
+		return reflect.Func, true
+	}
+
+	return reflect.Invalid, false
+}
+
+// Utility Methods
+
+func subdirectories(root string) ([]string, error) {
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !entry.IsDir() {
+			return nil
+		}
+
+		// directories ignored by the go tool are skipped
+		name := entry.Name()
+		if path != root && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+			return filepath.SkipDir
+		}
+
+		dirs = append(dirs, path)
+		return nil
+	})
+
+	return dirs, err
+}
+
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) == 0 {
+		// embedded fields are named after their type
+		expr := field.Type
+		if star, ok := expr.(*ast.StarExpr); ok {
+			expr = star.X
+		}
+		if selector, ok := expr.(*ast.SelectorExpr); ok {
+			expr = selector.Sel
+		}
+		if ident, ok := expr.(*ast.Ident); ok {
+			return []string{ident.Name}
+		}
+		return []string{"?"}
+	}
+
+	names := make([]string, len(field.Names))
+	for i, name := range field.Names {
+		names[i] = name.Name
+	}
+
+	return names
+}
+
+func hasFieldPath(pkg *types.Package, structType types.Type, path string) bool {
+	typ := structType
+	for _, name := range strings.Split(path, ".") {
+		if _, ok := gotypes.Deref(typ).Underlying().(*types.Struct); !ok {
+			return false
+		}
+
+		obj, _, _ := types.LookupFieldOrMethod(gotypes.Deref(typ), true, pkg, name)
+		field, ok := obj.(*types.Var)
+		if !ok || !field.IsField() {
+			return false
+		}
+		typ = field.Type()
+	}
+
+	return true
+}
+
+// editDistance returns the Levenshtein distance of two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(minInt(previous[j]+1, current[j-1]+1), previous[j-1]+cost)
+		}
+		previous = current
+	}
+
+	return previous[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,90 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator"
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+func eqFieldValidator() *cv.CustomValidator {
+	return cv.NewCustomValidator("eq-field", regexp.MustCompile(`eq-field\(.*\)`), func(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
+		return nil
+	}, cv.NewCustomValidatorConfig(), cv.FieldParam("field"))
+}
+
+// lines formats the diagnostics as line: field: message
+func lines(diagnostics []Diagnostic) []string {
+	formatted := make([]string, len(diagnostics))
+	for i, diagnostic := range diagnostics {
+		formatted[i] = fmt.Sprintf("%v: %v: %v", diagnostic.Pos.Line, diagnostic.Field, diagnostic.Message)
+	}
+
+	return formatted
+}
+
+func TestLinter_Run(t *testing.T) {
+	diagnostics, err := New(validator.NewValidator()).Run("testdata/example")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		`8: User.Name: unknown validator "requierd", did you mean "required"?`,
+		`9: User.Code: invalid arguments for validation len(abc): len: parameter length of type int cannot be parsed from "abc": strconv.Atoi: parsing "abc": invalid syntax: map[validator:len]`,
+		`10: User.Email: missing closing bracket: map[position:14 tag:(email||len(0)]`,
+		`11: User.Age: validator len does not support fields of kind int`,
+		`13: User.Scores: validator email does not support fields of kind int`,
+		`14: User.Count: dive validation does not support fields of kind int`,
+		`15: User.Password: unknown validator "eq-field"`,
+		`16: User.Confirm: unknown validator "eq-field"`,
+		`17: User.Nested: validator len does not support fields of kind struct`,
+		`23: Inner: validator email does not support fields of kind int`,
+	}, lines(diagnostics))
+	assert.Equal(t, "testdata/example/example.go:8:29: User.Name: unknown validator \"requierd\", did you mean \"required\"?", diagnostics[0].String())
+}
+
+func TestLinter_Run_customValidatorsAndTagKeys(t *testing.T) {
+	v := validator.NewValidator()
+	v.RegisterCustomValidator(eqFieldValidator())
+	v.SetTagKeys(validator.PlaygroundTagKey("validate"))
+
+	diagnostics, err := New(v).Run("testdata/example")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		`19: User.Legacy: invalid arguments for validation len(abc): len: parameter length of type int cannot be parsed from "abc": strconv.Atoi: parsing "abc": invalid syntax: map[validator:len]`,
+	}, lines(diagnostics))
+
+	v.SetTagKeys(validator.NativeTagKey(validator.DefaultTagKey))
+	diagnostics, err = New(v).Run("testdata/example")
+	assert.NoError(t, err)
+	assert.Contains(t, lines(diagnostics), "16: User.Confirm: validation eq-field(Missing) references unknown field Missing")
+	assert.NotContains(t, lines(diagnostics), `15: User.Password: unknown validator "eq-field"`)
+}
+
+func TestLinter_Run_ignore(t *testing.T) {
+	linter := New(validator.NewValidator())
+	linter.Ignore = []string{"eq-field"}
+
+	diagnostics, err := linter.Run("testdata/example")
+	assert.NoError(t, err)
+	assert.Len(t, diagnostics, 8)
+	assert.NotContains(t, lines(diagnostics), `15: User.Password: unknown validator "eq-field"`)
+	assert.NotContains(t, lines(diagnostics), `16: User.Confirm: unknown validator "eq-field"`)
+	assert.Contains(t, lines(diagnostics), `8: User.Name: unknown validator "requierd", did you mean "required"?`)
+}
+
+func TestLinter_Run_subdirectories(t *testing.T) {
+	diagnostics, err := New(validator.NewValidator()).Run("testdata/...")
+	assert.NoError(t, err)
+	assert.Len(t, diagnostics, 10)
+
+	// testdata directories are skipped like by the go tool unless they are the root
+	diagnostics, err = New(validator.NewValidator()).Run("./...")
+	assert.NoError(t, err)
+	assert.Empty(t, diagnostics)
+}
@@ -0,0 +1,27 @@
+package example
+
+type Address struct {
+	Street string `validator:"required"`
+}
+
+type User struct {
+	Name     string            `validator:"requierd"`
+	Code     string            `validator:"len(abc)"`
+	Email    string            `validator:"(email || len(0)"`
+	Age      int               `validator:"len(3)"`
+	Tags     []string          `validator:"dive(min(2) && email)"`
+	Scores   map[string]int    `validator:"dive(email)"`
+	Count    *int              `validator:"dive(required)"`
+	Password string            `validator:"eq-field(Confirm)"`
+	Confirm  string            `validator:"eq-field(Missing)"`
+	Nested   *Address          `validator:"non-nil && len(1)"`
+	Any      interface{}       `validator:"len(3)"`
+	Legacy   string            `validate:"required,len=abc"`
+	Valid    string            `validator:"required && len(9)"`
+	Other    map[string]string `json:"other"`
+	Anonymous struct {
+		Inner int `validator:"email"`
+	}
+	Empty   string `validator:""`
+	Ignored int    `validator:"-"`
+}
@@ -0,0 +1,195 @@
+package validator
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+var (
+	macroNameRegex  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+	macroParamRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// macro is a named expression that is expanded at parse time.
+// An alias is a macro without parameters.
+type macro struct {
+	name       string
+	params     []string
+	expression string
+}
+
+// RegisterAlias registers a named expression that can be used like a validation in tags.
+// The alias is expanded at parse time and its name is shown in error messages.
+// Usage:
+//
+//	validator.RegisterAlias("id", "required && len(36)")
+//	// `validator:"id"` is equal to `validator:"required && len(36)"`
+func (v *Validator) RegisterAlias(name, expression string) error {
+	if strings.Contains(name, "(") {
+		return SyntaxErrorf("alias %v must not have parameters, use RegisterMacro instead", name)
+	}
+
+	return v.RegisterMacro(name, expression)
+}
+
+// RegisterMacro registers a named expression with parameters that can be used like a validation in tags.
+// Parameters are declared in brackets after the name and referenced in the expression by a leading $.
+// Usage:
+//
+//	validator.RegisterMacro("bounded(lower,upper)", "min($lower) && max($upper)")
+//	// `validator:"bounded(1,10)"` is equal to `validator:"min(1) && max(10)"`
+func (v *Validator) RegisterMacro(signature, expression string) error {
+	name, params, err := cv.ParseSubTag(removeWhiteSpace(signature))
+	if err != nil {
+		return SyntaxErrorf("invalid macro signature %v: %v", signature, err)
+	}
+
+	if !macroNameRegex.MatchString(name) {
+		return SyntaxErrorf("invalid macro name %v", name)
+	}
+
+	m := &macro{
+		name:       name,
+		params:     params,
+		expression: removeWhiteSpace(expression),
+	}
+
+	declared := map[string]bool{}
+	for _, param := range params {
+		if !macroParamRegex.MatchString(param) || declared[param] {
+			return SyntaxErrorf("invalid parameter %v of macro %v", param, name)
+		}
+		declared[param] = true
+	}
+
+	// check that only declared parameters are referenced and the expression can be parsed
+	_, err = m.substitute(params)
+	if err != nil {
+		return err
+	}
+
+	_, err = parseTag(m.expression)
+	if err != nil {
+		return err
+	}
+
+	if v.macros == nil {
+		v.macros = map[string]*macro{}
+	}
+
+	v.macros[name] = m
+	v.plans.reset()
+
+	return nil
+}
+
+// substitute replaces the references to parameters in the expression of the macro by the provided arguments.
+// References within string literals are not replaced.
+func (m *macro) substitute(args []string) (string, error) {
+	if len(args) != len(m.params) {
+		return "", SyntaxErrorf("macro %v expects %v parameter(s) but got %v", m.name, len(m.params), len(args))
+	}
+
+	values := map[string]string{}
+	for i, param := range m.params {
+		values[param] = args[i]
+	}
+
+	var sb strings.Builder
+	expression := m.expression
+	for i := 0; i < len(expression); i++ {
+		if cv.IsQuote(expression[i]) {
+			end, err := cv.ScanLiteral(expression, i)
+			if err != nil {
+				return "", SyntaxErrorf("invalid expression of macro %v: %v", m.name, err)
+			}
+			sb.WriteString(expression[i:end])
+			i = end - 1
+			continue
+		}
+
+		if expression[i] != '$' {
+			sb.WriteByte(expression[i])
+			continue
+		}
+
+		end := i + 1
+		for end < len(expression) && isIdentifierChar(expression[end]) {
+			end++
+		}
+
+		value, ok := values[expression[i+1:end]]
+		if !ok {
+			return "", SyntaxErrorf("macro %v references unknown parameter %v", m.name, expression[i:end])
+		}
+		sb.WriteString(value)
+		i = end - 1
+	}
+
+	return sb.String(), nil
+}
+
+// expand replaces all validation nodes referencing an alias or macro by alias nodes containing their parsed expressions.
+// The stack contains the names of all aliases and macros that are currently expanded to detect cycles.
+func (v *Validator) expand(node *Node, stack []string) (*Node, error) {
+	if node == nil || len(v.macros) == 0 {
+		return node, nil
+	}
+
+	if node.Kind != ValidationNode {
+		for i, child := range node.Children {
+			expanded, err := v.expand(child, stack)
+			if err != nil {
+				return nil, err
+			}
+			node.Children[i] = expanded
+		}
+
+		return node, nil
+	}
+
+	name, args, err := cv.ParseSubTag(node.SubTag)
+	if err != nil {
+		// the sub tag is no alias and will be reported when it is bound to custom validators
+		return node, nil
+	}
+
+	m, ok := v.macros[name]
+	if !ok {
+		return node, nil
+	}
+
+	for _, expanding := range stack {
+		if expanding == name {
+			return nil, SyntaxErrorf("alias %v is cyclic: %v -> %v", name, strings.Join(stack, " -> "), name)
+		}
+	}
+
+	expression, err := m.substitute(args)
+	if err != nil {
+		return nil, err
+	}
+
+	expanded, err := parseTag(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	expanded, err = v.expand(expanded, append(stack, name))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Node{
+		Kind:     AliasNode,
+		SubTag:   node.SubTag,
+		Children: []*Node{expanded},
+		Message:  node.Message,
+	}, nil
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type AliasStruct struct {
+	ID     string `validator:"id"`
+	Amount int    `validator:"bounded(1, 10)"`
+	Email  string `validator:"contact || len(0)"`
+}
+
+type CyclicAliasStruct struct {
+	Field string `validator:"first"`
+}
+
+func newAliasValidator(t *testing.T) *Validator {
+	validator := NewValidator()
+
+	assert.NoError(t, validator.RegisterAlias("id", "required && len(36)"))
+	assert.NoError(t, validator.RegisterMacro("bounded(lower,upper)", "min($lower) && max($upper)"))
+	assert.NoError(t, validator.RegisterAlias("contact", "email && bounded(5, 30)"))
+
+	return validator
+}
+
+func TestValidator_Validate_alias(t *testing.T) {
+	validator := newAliasValidator(t)
+
+	values := []AliasStruct{
+		{ID: "123e4567-e89b-12d3-a456-426614174000", Amount: 1, Email: ValidEmail},
+		{ID: "123e4567-e89b-12d3-a456-426614174000", Amount: 10},
+	}
+
+	for _, val := range values {
+		err := validator.Validate(context.Background(), val)
+		assert.NoError(t, err)
+	}
+}
+
+func TestValidator_Validate_aliasFails(t *testing.T) {
+	validator := newAliasValidator(t)
+
+	err := validator.Validate(context.Background(), AliasStruct{ID: "123", Amount: 1})
+	assert.EqualError(t, err, "validation of alias id of Field ID failed: && validation of required and len(36) of Field ID failed")
+
+	err = validator.Validate(context.Background(), AliasStruct{ID: "123e4567-e89b-12d3-a456-426614174000", Amount: 11})
+	assert.EqualError(t, err, "validation of alias bounded(1,10) of Field Amount failed: && validation of min(1) and max(10) of Field Amount failed")
+}
+
+func TestValidator_Validate_failsForCyclicAlias(t *testing.T) {
+	validator := NewValidator()
+	assert.NoError(t, validator.RegisterAlias("first", "required && second"))
+	assert.NoError(t, validator.RegisterAlias("second", "email || first"))
+
+	err := validator.Validate(context.Background(), CyclicAliasStruct{Field: ValidEmail})
+
+	assert.IsType(t, &TagSyntaxError{}, err)
+	assert.Contains(t, err.Error(), "alias first is cyclic: first -> second -> first")
+}
+
+func TestValidator_Validate_failsForWrongMacroArity(t *testing.T) {
+	validator := newAliasValidator(t)
+
+	err := validator.Validate(context.Background(), CyclicAliasStruct{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, validator.RegisterAlias("first", "bounded(1)"))
+
+	err = validator.Validate(context.Background(), CyclicAliasStruct{})
+	assert.IsType(t, &TagSyntaxError{}, err)
+}
+
+func TestValidator_RegisterMacro_failsForInvalidMacros(t *testing.T) {
+	validator := NewValidator()
+
+	macros := map[string]string{
+		"bounded(lower,upper)":  "min($lower) && max($unknown)",
+		"bounded(lower,lower)":  "min($lower)",
+		"bounded(lower":         "min($lower)",
+		"(lower)":               "min($lower)",
+		"bounded(lower, upper)": "min($lower) &&",
+	}
+
+	for signature, expression := range macros {
+		t.Run(signature, func(t *testing.T) {
+			err := validator.RegisterMacro(signature, expression)
+
+			assert.IsType(t, &TagSyntaxError{}, err)
+		})
+	}
+
+	err := validator.RegisterAlias("alias(param)", "required")
+	assert.IsType(t, &TagSyntaxError{}, err)
+}
+
+func TestMacro_substitute_ignoresStringLiterals(t *testing.T) {
+	validator := NewValidator()
+	assert.NoError(t, validator.RegisterMacro("pattern(value)", "regex('^$value$') || oneof($value)"))
+
+	expression, err := validator.macros["pattern"].substitute([]string{"'a'"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "regex('^$value$')||oneof('a')", expression)
+}
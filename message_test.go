@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator/dv"
+)
+
+type MessageUser struct {
+	Name    string `validator:"len(9)#msg('Your username must be 9 characters')"`
+	Email   string `validator:"(email || len(0))#msg(email.invalid)"`
+	Age     int    `validator:"min(18)" validator-msg:"You must be an adult"`
+	Nick    string `validator:"nick" label:"Spitzname"`
+	Country string `validator:"oneof(de,en)#msg(country)"`
+}
+
+func TestValidator_Validate_replacesMessages(t *testing.T) {
+	validator := NewValidator()
+	err := validator.RegisterAlias("nick", "len(3)#msg('The nickname must be 3 characters')")
+	assert.NoError(t, err)
+
+	valid := MessageUser{Name: "012345678", Age: 18, Nick: "abc", Country: "de"}
+
+	user := valid
+	user.Name = "short"
+	err = validator.Validate(context.Background(), user)
+	assert.EqualError(t, err, "Your username must be 9 characters")
+
+	var fieldErr *FieldError
+	if assert.True(t, errors.As(err, &fieldErr)) {
+		assert.Equal(t, "len", fieldErr.Validator)
+		assert.Equal(t, "Your username must be 9 characters", fieldErr.MessageKey)
+		assert.IsType(t, dv.LenError(""), errors.Unwrap(err))
+		assert.EqualError(t, errors.Unwrap(err), "len field Name has length 5, but should have length 9")
+	}
+
+	user = valid
+	user.Email = "invalid"
+	err = validator.Validate(context.Background(), user)
+	assert.EqualError(t, err, "email.invalid")
+	if assert.True(t, errors.As(err, &fieldErr)) {
+		assert.Equal(t, "or", fieldErr.Validator)
+	}
+
+	user = valid
+	user.Age = 17
+	err = validator.Validate(context.Background(), user)
+	assert.EqualError(t, err, "You must be an adult")
+
+	user = valid
+	user.Nick = "ab"
+	err = validator.Validate(context.Background(), user)
+	assert.EqualError(t, err, "validation of alias nick of Field Nick failed: The nickname must be 3 characters")
+}
+
+func TestValidator_Translate_messageKeys(t *testing.T) {
+	validator := NewValidator()
+	err := validator.RegisterCatalog("de", Catalog{Messages: map[string]string{
+		"email.invalid": "{{.Label}} muss eine E-Mail-Adresse oder leer sein",
+		"country":       "{{.Label}} muss {{join .Params \" oder \"}} sein",
+	}})
+	assert.NoError(t, err)
+
+	valid := MessageUser{Name: "012345678", Age: 18, Nick: "abc", Country: "de"}
+	ctx := WithLocale(context.Background(), "de")
+
+	user := valid
+	user.Email = "invalid"
+	err = validator.Validate(ctx, user)
+	assert.EqualError(t, err, "Email muss eine E-Mail-Adresse oder leer sein")
+
+	user = valid
+	user.Country = "fr"
+	err = validator.Validate(ctx, user)
+	assert.EqualError(t, err, "Country muss de oder en sein")
+
+	// messages which are no keys of the catalog are kept
+	user = valid
+	user.Name = "short"
+	err = validator.Validate(ctx, user)
+	assert.EqualError(t, err, "Your username must be 9 characters")
+}
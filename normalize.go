@@ -0,0 +1,182 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// NormalizeTagKey is the struct field tag key containing the IDs of the modifiers that are applied to a field by Normalize
+// separated by commas e.g. `normalize:"trim,lower"`
+const NormalizeTagKey = "normalize"
+
+// RegisterModifier registers a modifier of the normalization for the validator.
+func (v *Validator) RegisterModifier(modifier *cv.Modifier) {
+	if v.Modifiers == nil {
+		v.Modifiers = map[string]*cv.Modifier{}
+	}
+
+	v.Modifiers[modifier.ID] = modifier
+}
+
+// Normalize modifies the fields of the struct the provided pointer points to by the modifiers of their normalize tags,
+// which are applied from left to right, and forwards the provided context to all modifiers.
+// Strings, pointers to strings as well as slices, arrays and map values of them are modified. Nested structs and elements of slices
+// and arrays of structs are normalized as well. Normalize is supposed to be called before the validation.
+// Returns an error if a modifier is not registered or a tag is on a field of an unsupported kind.
+func (v *Validator) Normalize(ctx context.Context, i interface{}) error {
+	ptrValue := reflect.ValueOf(i)
+	if ptrValue.Kind() != reflect.Ptr || ptrValue.IsNil() || ptrValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("normalization requires a non-nil pointer to a struct but got %T", i)
+	}
+
+	return v.normalizeStruct(ctx, ptrValue.Elem(), nil)
+}
+
+func (v *Validator) normalizeStruct(ctx context.Context, structValue reflect.Value, parent *cv.Field) error {
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+
+		field := &cv.Field{
+			Parent:      parent,
+			StructField: structField,
+			Value:       structValue.Field(i),
+			Struct:      structValue,
+		}
+
+		tag, ok := structField.Tag.Lookup(NormalizeTagKey)
+		if !ok {
+			err := v.normalizeNested(ctx, field.Value, field)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !field.Value.CanSet() {
+			continue
+		}
+
+		modifiers, err := v.modifiers(tag, field)
+		if err != nil {
+			return err
+		}
+
+		err = modify(ctx, field.Value, field, modifiers)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// normalizeNested normalizes an untagged field of kind struct, pointer to struct or slices and arrays of them
+func (v *Validator) normalizeNested(ctx context.Context, value reflect.Value, field *cv.Field) error {
+	switch value.Kind() {
+	case reflect.Struct:
+		return v.normalizeStruct(ctx, value, field)
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+
+		elem := value.Elem()
+		if elem.Kind() == reflect.Ptr || elem.CanSet() {
+			return v.normalizeNested(ctx, elem, field)
+		}
+	case reflect.Slice, reflect.Array:
+		elemKind := getUnderlyingType(value.Type().Elem()).Kind()
+		if elemKind != reflect.Struct {
+			return nil
+		}
+
+		for i := 0; i < value.Len(); i++ {
+			err := v.normalizeNested(ctx, value.Index(i), elemField(field, i, value.Index(i)))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// modifiers returns the registered modifiers of a normalize tag
+func (v *Validator) modifiers(tag string, field *cv.Field) ([]*cv.Modifier, error) {
+	var modifiers []*cv.Modifier
+	for _, id := range strings.Split(tag, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+
+		modifier, ok := v.Modifiers[id]
+		if !ok {
+			return nil, fmt.Errorf("modifier %v of Field %v is not registered", id, getFullFieldName(field))
+		}
+		modifiers = append(modifiers, modifier)
+	}
+
+	return modifiers, nil
+}
+
+// modify applies the modifiers to a string value or the string elements of a value
+func modify(ctx context.Context, value reflect.Value, field *cv.Field, modifiers []*cv.Modifier) error {
+	switch value.Kind() {
+	case reflect.String:
+		s := value.String()
+		for _, modifier := range modifiers {
+			s = modifier.Modify(ctx, s)
+		}
+		value.SetString(s)
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+
+		elem := value.Elem()
+		if elem.Kind() == reflect.String && !elem.CanSet() {
+			// strings in interfaces cannot be set and are replaced instead
+			modified := reflect.New(elem.Type()).Elem()
+			modified.Set(elem)
+			err := modify(ctx, modified, field, modifiers)
+			if err != nil {
+				return err
+			}
+
+			value.Set(modified)
+			return nil
+		}
+
+		return modify(ctx, elem, field, modifiers)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			err := modify(ctx, value.Index(i), field, modifiers)
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := value.MapRange()
+		for iter.Next() {
+			// map values cannot be set and are replaced instead
+			modified := reflect.New(iter.Value().Type()).Elem()
+			modified.Set(iter.Value())
+			err := modify(ctx, modified, field, modifiers)
+			if err != nil {
+				return err
+			}
+
+			value.SetMapIndex(iter.Key(), modified)
+		}
+	default:
+		return fmt.Errorf("normalization of Field %v of kind %v is not supported", getFullFieldName(field), value.Kind())
+	}
+
+	return nil
+}
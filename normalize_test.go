@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+type NormalizeUser struct {
+	Email    string            `normalize:"trim,lower" validator:"email"`
+	Name     *string           `normalize:"collapse,trim,title"`
+	Code     string            `normalize:"upper"`
+	Nick     string            `normalize:"nfc"`
+	Bio      string            `normalize:"strip-control"`
+	Tags     []string          `normalize:"trim"`
+	Labels   map[string]string `normalize:"lower"`
+	Any      interface{}       `normalize:"trim"`
+	Address  *NormalizeAddress
+	Contacts []NormalizeAddress
+	Untagged string
+}
+
+type NormalizeAddress struct {
+	City string `normalize:"trim"`
+}
+
+func TestValidator_Normalize(t *testing.T) {
+	validator := NewValidator()
+
+	name := "  jane   \t van  DOE "
+	user := NormalizeUser{
+		Email:    "  Jane@Example.COM ",
+		Name:     &name,
+		Code:     "de",
+		Nick:     "Jose\u0301",
+		Bio:      "line\x00break\n",
+		Tags:     []string{" a ", "b "},
+		Labels:   map[string]string{"Key": "VALUE"},
+		Any:      " any ",
+		Address:  &NormalizeAddress{City: " Berlin "},
+		Contacts: []NormalizeAddress{{City: " Rome"}},
+		Untagged: " untagged ",
+	}
+
+	assert.Error(t, validator.Validate(context.Background(), user))
+
+	err := validator.Normalize(context.Background(), &user)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "jane@example.com", user.Email)
+	assert.Equal(t, "Jane Van Doe", *user.Name)
+	assert.Equal(t, "DE", user.Code)
+	assert.Equal(t, "Jos\u00e9", user.Nick)
+	assert.Equal(t, "linebreak", user.Bio)
+	assert.Equal(t, []string{"a", "b"}, user.Tags)
+	assert.Equal(t, map[string]string{"Key": "value"}, user.Labels)
+	assert.Equal(t, "any", user.Any)
+	assert.Equal(t, "Berlin", user.Address.City)
+	assert.Equal(t, "Rome", user.Contacts[0].City)
+	assert.Equal(t, " untagged ", user.Untagged)
+
+	assert.NoError(t, validator.Validate(context.Background(), user))
+}
+
+func TestValidator_RegisterModifier(t *testing.T) {
+	validator := NewValidator()
+	validator.RegisterModifier(cv.NewModifier("digits", func(ctx context.Context, value string) string {
+		digits := []rune{}
+		for _, r := range value {
+			if r >= '0' && r <= '9' {
+				digits = append(digits, r)
+			}
+		}
+		return string(digits)
+	}))
+
+	type phone struct {
+		Number string `normalize:"trim, digits" validator:"len(6)"`
+	}
+
+	p := phone{Number: " 12-34-56 "}
+	assert.NoError(t, validator.Normalize(context.Background(), &p))
+	assert.Equal(t, "123456", p.Number)
+}
+
+func TestValidator_Normalize_errors(t *testing.T) {
+	validator := NewValidator()
+
+	err := validator.Normalize(context.Background(), NormalizeUser{})
+	assert.EqualError(t, err, "normalization requires a non-nil pointer to a struct but got validator.NormalizeUser")
+
+	type unknownModifier struct {
+		Nested struct {
+			Name string `normalize:"trim,reverse"`
+		}
+	}
+	err = validator.Normalize(context.Background(), &unknownModifier{})
+	assert.EqualError(t, err, "modifier reverse of Field Nested.Name is not registered")
+
+	type unsupportedKind struct {
+		Count int `normalize:"trim"`
+	}
+	err = validator.Normalize(context.Background(), &unsupportedKind{})
+	assert.EqualError(t, err, "normalization of Field Count of kind int is not supported")
+}
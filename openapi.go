@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPIVersion is the version of the OpenAPI documents created by OpenAPIComponents
+const OpenAPIVersion = "3.1.0"
+
+// OpenAPI is an OpenAPI document which only contains reusable components
+type OpenAPI struct {
+	OpenAPI    string                  `json:"openapi" yaml:"openapi"`
+	Info       OpenAPIInfo             `json:"info" yaml:"info"`
+	Components OpenAPIComponentsObject `json:"components" yaml:"components"`
+}
+
+// OpenAPIInfo is the metadata of an OpenAPI document
+type OpenAPIInfo struct {
+	Title   string `json:"title" yaml:"title"`
+	Version string `json:"version" yaml:"version"`
+}
+
+// OpenAPIComponentsObject contains the schemas of an OpenAPI document by their names
+type OpenAPIComponentsObject struct {
+	Schemas map[string]Schema `json:"schemas" yaml:"schemas"`
+}
+
+// JSON encodes the OpenAPI document as indented JSON
+func (doc *OpenAPI) JSON() ([]byte, error) {
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// YAML encodes the OpenAPI document as YAML indented by two spaces
+func (doc *OpenAPI) YAML() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	err := encoder.Encode(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// OpenAPIComponents creates an OpenAPI document containing a schema of each provided struct type by the default custom validators.
+// See Validator.OpenAPIComponents for details.
+// Usage:
+//
+//	doc, err := validator.OpenAPIComponents(CreateUserRequest{}, UserResponse{})
+//	data, err := doc.YAML()
+func OpenAPIComponents(types ...interface{}) (*OpenAPI, error) {
+	return NewValidator().OpenAPIComponents(types...)
+}
+
+// OpenAPIComponents creates an OpenAPI 3.1 document containing a schema of each provided struct type in components/schemas.
+// The schemas are created like by JSONSchema and named by their types. Nested named struct types are added to the components
+// as well and shared by references. The title and version of the document should be set before it is published.
+func (v *Validator) OpenAPIComponents(types ...interface{}) (*OpenAPI, error) {
+	g := newSchemaGenerator(v, "#/components/schemas/")
+
+	for _, i := range types {
+		rType := reflect.TypeOf(i)
+		for rType != nil && rType.Kind() == reflect.Ptr {
+			rType = rType.Elem()
+		}
+
+		if rType == nil || rType.Kind() != reflect.Struct || rType.Name() == "" {
+			return nil, fmt.Errorf("openapi component of %T is not supported, only named structs are supported", i)
+		}
+
+		_, err := g.ref(rType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &OpenAPI{
+		OpenAPI:    OpenAPIVersion,
+		Info:       OpenAPIInfo{Title: "Components", Version: "0.0.0"},
+		Components: OpenAPIComponentsObject{Schemas: g.defs},
+	}, nil
+}
@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type OpenAPIUserRequest struct {
+	Name    string          `json:"name" validator:"required && max(20)" doc:"The display name" example:"Jane"`
+	Age     int             `json:"age" validator:"min(18)" example:"42"`
+	Roles   []string        `json:"roles" validator:"dive(oneof(admin,user))" example:"[\"admin\"]"`
+	Address *OpenAPIAddress `json:"address" validator:"non-nil" doc:"The postal address"`
+}
+
+type OpenAPIUserResponse struct {
+	ID      string         `json:"id" validator:"len(36)"`
+	Address OpenAPIAddress `json:"address"`
+}
+
+type OpenAPIAddress struct {
+	City string `json:"city" validator:"non-zero"`
+}
+
+func TestValidator_OpenAPIComponents(t *testing.T) {
+	doc, err := OpenAPIComponents(OpenAPIUserRequest{}, &OpenAPIUserResponse{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	data, err := doc.JSON()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"openapi": "3.1.0",
+		"info": {"title": "Components", "version": "0.0.0"},
+		"components": {
+			"schemas": {
+				"OpenAPIUserRequest": {
+					"type": "object",
+					"properties": {
+						"name": {"type": "string", "minLength": 1, "maxLength": 20, "description": "The display name", "examples": ["Jane"]},
+						"age": {"type": "integer", "minimum": 18, "examples": [42]},
+						"roles": {"type": "array", "items": {"type": "string", "enum": ["admin", "user"]}, "examples": [["admin"]]},
+						"address": {"$ref": "#/components/schemas/OpenAPIAddress", "description": "The postal address"}
+					},
+					"required": ["name", "address"]
+				},
+				"OpenAPIUserResponse": {
+					"type": "object",
+					"properties": {
+						"id": {"type": "string", "maxLength": 36},
+						"address": {"$ref": "#/components/schemas/OpenAPIAddress"}
+					}
+				},
+				"OpenAPIAddress": {
+					"type": "object",
+					"properties": {
+						"city": {"type": "string", "minLength": 1}
+					},
+					"required": ["city"]
+				}
+			}
+		}
+	}`, string(data))
+}
+
+func TestOpenAPI_YAML(t *testing.T) {
+	doc, err := OpenAPIComponents(OpenAPIAddress{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	data, err := doc.YAML()
+	assert.NoError(t, err)
+	assert.Equal(t, `openapi: 3.1.0
+info:
+  title: Components
+  version: 0.0.0
+components:
+  schemas:
+    OpenAPIAddress:
+      properties:
+        city:
+          minLength: 1
+          type: string
+      required:
+        - city
+      type: object
+`, string(data))
+}
+
+func TestValidator_OpenAPIComponents_fails(t *testing.T) {
+	_, err := OpenAPIComponents(struct{}{})
+	assert.EqualError(t, err, "openapi component of struct {} is not supported, only named structs are supported")
+
+	type InvalidExample struct {
+		Age int `example:"old"`
+	}
+	_, err = OpenAPIComponents(InvalidExample{})
+	assert.EqualError(t, err, `field InvalidExample.Age: example "old" cannot be parsed: invalid character 'o' looking for beginning of value`)
+}
@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// PanicError is returned by the validation instead of panicking if a custom validator or the validation itself panicked
+type PanicError struct {
+	// Path is the full name of the field that was validated e.g. Address.Street or empty if the panic did not occur on a single field
+	Path string
+	// Validator is the id of the custom validator that panicked or empty if the validation itself panicked
+	Validator string
+	// Value is the value the validation panicked with
+	Value interface{}
+	// Stack is the stack trace of the goroutine at the time of the panic
+	Stack []byte
+}
+
+// Error returns the error's message string
+// Implements error interface
+func (err *PanicError) Error() string {
+	switch {
+	case err.Validator != "" && err.Path != "":
+		return fmt.Sprintf("validator %v panicked on Field %v: %v", err.Validator, err.Path, err.Value)
+	case err.Validator != "":
+		return fmt.Sprintf("validator %v panicked: %v", err.Validator, err.Value)
+	case err.Path != "":
+		return fmt.Sprintf("validation of Field %v panicked: %v", err.Path, err.Value)
+	}
+
+	return fmt.Sprintf("validation panicked: %v", err.Value)
+}
+
+// Unwrap returns the value the validation panicked with if it is an error
+func (err *PanicError) Unwrap() error {
+	cause, _ := err.Value.(error)
+	return cause
+}
+
+// newPanicError creates a new panic error for a recovered value or returns the value if it is a panic error already
+func newPanicError(recovered interface{}, field *cv.Field, validatorID string) *PanicError {
+	if panicErr, ok := recovered.(*PanicError); ok {
+		return panicErr
+	}
+
+	return &PanicError{
+		Path:      getFullFieldName(field),
+		Validator: validatorID,
+		Value:     recovered,
+		Stack:     debug.Stack(),
+	}
+}
+
+// repanic converts a panic of the validation of a field into a panic error and panics again,
+// so that the panic error is returned by the validation instead of an error of a logical operator
+func repanic(field *cv.Field, validatorID string) {
+	if recovered := recover(); recovered != nil {
+		panic(newPanicError(recovered, field, validatorID))
+	}
+}
+
+// recoverPanic sets the error of the validation to a panic error if the validation panicked
+func recoverPanic(err *error) {
+	if recovered := recover(); recovered != nil {
+		*err = newPanicError(recovered, nil, "")
+	}
+}
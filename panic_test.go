@@ -0,0 +1,121 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+type PanickingUser struct {
+	Name    string `validator:"len(3)"`
+	secret  string `validator:"interface || len(0)"`
+	Address PanickingAddress
+}
+
+type PanickingAddress struct {
+	Street string `validator:"panic"`
+}
+
+func newPanickingValidator() *Validator {
+	validator := NewValidator()
+	validator.RegisterCustomValidator(cv.NewCustomValidator("interface", regexp.MustCompile("^interface$"),
+		func(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
+			// Interface panics on values of unexported fields
+			_ = f.Value.Interface()
+			return nil
+		}, cv.NewCustomValidatorConfig()))
+	validator.RegisterCustomValidator(cv.NewCustomValidator("panic", regexp.MustCompile("^panic$"),
+		func(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
+			var streets map[string]*string
+			if f.Value.String() == "" {
+				*streets["main"] = "Main"
+			}
+			return nil
+		}, cv.NewCustomValidatorConfig()))
+
+	return validator
+}
+
+func TestValidator_Validate_recoversPanics(t *testing.T) {
+	validator := newPanickingValidator()
+
+	// the panic is not hidden by the || operator
+	err := validator.Validate(context.Background(), PanickingUser{Name: "abc"})
+	var panicErr *PanicError
+	if assert.True(t, errors.As(err, &panicErr)) {
+		assert.Equal(t, "secret", panicErr.Path)
+		assert.Equal(t, "interface", panicErr.Validator)
+		assert.Contains(t, string(panicErr.Stack), "newPanickingValidator")
+		assert.Contains(t, err.Error(), "validator interface panicked on Field secret: reflect.Value.Interface")
+	}
+
+	type NestedUser struct {
+		Address *PanickingAddress
+	}
+	err = validator.ValidateAll(context.Background(), NestedUser{Address: &PanickingAddress{}})
+	if assert.True(t, errors.As(err, &panicErr)) {
+		assert.Equal(t, "Address.Street", panicErr.Path)
+		assert.Equal(t, "panic", panicErr.Validator)
+		assert.True(t, errors.As(err, new(interface{ RuntimeError() })))
+	}
+
+	address := PanickingAddress{}
+	err = validator.ValidateStruct(context.Background(), &address, Field(&address.Street, NewValidationNode("len(3)")))
+	assert.True(t, errors.As(err, &panicErr))
+
+	trace, err := validator.Trace(context.Background(), address)
+	assert.True(t, errors.As(err, &panicErr))
+	assert.Len(t, trace.Fields, 1)
+}
+
+func TestValidator_Validate_malformedInput(t *testing.T) {
+	validator := NewValidator()
+
+	assert.EqualError(t, validator.Validate(context.Background(), nil), "validation of a nil value is not supported")
+	assert.EqualError(t, validator.ValidateAll(context.Background(), nil), "validation of a nil value is not supported")
+
+	type Nested struct {
+		Value interface{}
+		Name  string `validator:"len(3)"`
+	}
+	type Any struct {
+		Value  interface{}
+		Nested *Nested
+	}
+	assert.NoError(t, validator.Validate(context.Background(), Any{Nested: &Nested{Name: "abc"}}))
+	assert.NoError(t, validator.Validate(context.Background(), Any{Value: (*int)(nil), Nested: &Nested{Name: "abc"}}))
+	assert.EqualError(t, validator.Validate(context.Background(), (*Any)(nil)),
+		"validation failed since validator for regex: len\\(.*\\) failed on nil value for Field: Nested.Name")
+}
+
+func TestValidator_Validate_recoversRemotePanics(t *testing.T) {
+	validator := NewValidator()
+	validator.RegisterRemoteValidator(NewRemoteValidator("exists", regexp.MustCompile(`^exists\(.*\)$`), RemoteBackendFunc(
+		func(ctx context.Context, vCtx *cv.ValidationContext, keys []string) (map[string]bool, error) {
+			panic("connection lost")
+		}), cv.StringParam("table")))
+
+	err := validator.Validate(context.Background(), RemoteOwner{Owner: "alice"})
+	var remoteErr *RemoteError
+	var panicErr *PanicError
+	if assert.True(t, errors.As(err, &remoteErr)) && assert.True(t, errors.As(err, &panicErr)) {
+		assert.Equal(t, "exists", panicErr.Validator)
+		assert.Equal(t, "exists field Owner: lookup of value alice failed: validator exists panicked: connection lost", err.Error())
+	}
+}
+
+func TestPanicError_Error(t *testing.T) {
+	cause := errors.New("boom")
+
+	assert.EqualError(t, &PanicError{Path: "Name", Validator: "len", Value: cause}, "validator len panicked on Field Name: boom")
+	assert.EqualError(t, &PanicError{Validator: "exists", Value: cause}, "validator exists panicked: boom")
+	assert.EqualError(t, &PanicError{Path: "Name", Value: cause}, "validation of Field Name panicked: boom")
+	assert.EqualError(t, &PanicError{Value: "boom"}, "validation panicked: boom")
+	assert.ErrorIs(t, &PanicError{Value: cause}, cause)
+	assert.Nil(t, (&PanicError{Value: "boom"}).Unwrap())
+}
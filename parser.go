@@ -0,0 +1,289 @@
+package validator
+
+import (
+	"strings"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// tagParser parses validator tags of the native syntax into an expression tree.
+//
+// The grammar of the native syntax is:
+//
+//	expression := annotated [ ("&&" | "||") expression ]
+//	annotated  := unary [ "#msg(" message ")" ]
+//	unary      := "!" unary | primary
+//	primary    := "(" expression ")" | condition | dive | validation
+//	condition  := "if(" expression ")then(" expression ")" { "elif(" expression ")then(" expression ")" } [ "else(" expression ")" ]
+//	dive       := "dive(" expression ")"
+//
+// The logical operators && and || share the same precedence and are right associative,
+// e.g. a && b || c is evaluated as a && (b || c).
+// A message replaces the message of the error of the annotated expression, e.g. len(9)#msg('username.length').
+type tagParser struct {
+	tag string
+	pos int
+}
+
+// parseTag parses a validator tag of the native syntax.
+// Returns nil if the tag does not contain any validation.
+func parseTag(tag string) (*Node, error) {
+	strippedTag := removeWhiteSpace(tag)
+	if strippedTag == "" {
+		return nil, nil
+	}
+
+	p := &tagParser{tag: strippedTag}
+
+	node, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tag) {
+		return nil, p.errorf("unexpected closing bracket")
+	}
+
+	return node, nil
+}
+
+func (p *tagParser) parseExpression() (*Node, error) {
+	start := p.pos
+
+	left, err := p.parseAnnotated()
+	if err != nil {
+		return nil, err
+	}
+
+	var kind NodeKind
+	if p.hasPrefix("&&") {
+		kind = AndNode
+	} else if p.hasPrefix("||") {
+		kind = OrNode
+	} else {
+		return left, nil
+	}
+	p.pos += 2
+
+	right, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Node{
+		Kind:     kind,
+		SubTag:   p.tag[start:p.pos],
+		Children: []*Node{left, right},
+	}, nil
+}
+
+// parseAnnotated parses an unary expression which is optionally followed by a message
+func (p *tagParser) parseAnnotated() (*Node, error) {
+	node, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.hasPrefix("#") {
+		return node, nil
+	}
+
+	if !p.hasPrefix("#msg(") {
+		return nil, p.errorf("expected #msg( annotation")
+	}
+	p.pos += len("#msg(")
+
+	start := p.pos
+	if p.pos < len(p.tag) && cv.IsQuote(p.tag[p.pos]) {
+		end, err := cv.ScanLiteral(p.tag, p.pos)
+		if err != nil {
+			return nil, p.errorf("%v", err)
+		}
+		p.pos = end
+	} else {
+		for p.pos < len(p.tag) && p.tag[p.pos] != ')' {
+			p.pos++
+		}
+	}
+
+	if !p.hasPrefix(")") {
+		return nil, p.errorf("missing closing bracket")
+	}
+
+	message, err := cv.Unquote(p.tag[start:p.pos])
+	if err != nil {
+		return nil, p.errorf("%v", err)
+	}
+	p.pos++
+
+	if message == "" {
+		return nil, p.errorf("empty message")
+	}
+
+	if node.Message != "" {
+		return nil, p.errorf("expression has multiple messages")
+	}
+	node.Message = message
+
+	return node, nil
+}
+
+func (p *tagParser) parseUnary() (*Node, error) {
+	start := p.pos
+
+	if !p.hasPrefix("!") {
+		return p.parsePrimary()
+	}
+	p.pos++
+
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Node{
+		Kind:     NotNode,
+		SubTag:   p.tag[start:p.pos],
+		Children: []*Node{operand},
+	}, nil
+}
+
+func (p *tagParser) parsePrimary() (*Node, error) {
+	switch {
+	case p.hasPrefix("("):
+		return p.parseStatement("")
+	case p.hasPrefix("if("):
+		return p.parseCondition()
+	case p.hasPrefix("dive("):
+		start := p.pos
+
+		elem, err := p.parseStatement("dive")
+		if err != nil {
+			return nil, err
+		}
+
+		return &Node{
+			Kind:     DiveNode,
+			SubTag:   p.tag[start:p.pos],
+			Children: []*Node{elem},
+		}, nil
+	}
+
+	return p.parseValidation()
+}
+
+// parseStatement parses an expression in brackets that is preceded by the provided keyword e.g. then(...)
+func (p *tagParser) parseStatement(keyword string) (*Node, error) {
+	if !p.hasPrefix(keyword + "(") {
+		return nil, p.errorf("expected %v( statement", keyword)
+	}
+	p.pos += len(keyword) + 1
+
+	node, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.hasPrefix(")") {
+		return nil, p.errorf("missing closing bracket")
+	}
+	p.pos++
+
+	return node, nil
+}
+
+func (p *tagParser) parseCondition() (*Node, error) {
+	start := p.pos
+
+	condition, err := p.parseStatement("if")
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.hasPrefix("then(") {
+		return nil, p.errorf("if condition must be followed by then statement")
+	}
+
+	then, err := p.parseStatement("then")
+	if err != nil {
+		return nil, err
+	}
+
+	node := &Node{
+		Kind:     IfNode,
+		Children: []*Node{condition, then},
+	}
+
+	if p.hasPrefix("elif(") {
+		// parse the elif statement as nested if statement
+		p.pos += 2
+
+		elif, err := p.parseCondition()
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, elif)
+	} else if p.hasPrefix("else(") {
+		otherwise, err := p.parseStatement("else")
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, otherwise)
+	}
+
+	node.SubTag = p.tag[start:p.pos]
+
+	return node, nil
+}
+
+// parseValidation reads a single validation sub tag until a logical operator, a message or unmatched closing bracket is reached.
+// String literals are part of the sub tag and may contain any character.
+func (p *tagParser) parseValidation() (*Node, error) {
+	start := p.pos
+
+	numOpenBraces := 0
+	for p.pos < len(p.tag) {
+		if numOpenBraces == 0 && (p.hasPrefix("&&") || p.hasPrefix("||") || p.hasPrefix(")") || p.hasPrefix("#")) {
+			break
+		}
+
+		if cv.IsQuote(p.tag[p.pos]) {
+			// operators and brackets within string literals are ignored
+			end, err := cv.ScanLiteral(p.tag, p.pos)
+			if err != nil {
+				return nil, p.errorf("%v", err)
+			}
+			p.pos = end
+			continue
+		}
+
+		if p.tag[p.pos] == '(' {
+			numOpenBraces++
+		} else if p.tag[p.pos] == ')' {
+			numOpenBraces--
+		}
+		p.pos++
+	}
+
+	if numOpenBraces > 0 {
+		return nil, p.errorf("missing closing bracket")
+	}
+
+	if p.pos == start {
+		return nil, p.errorf("expected validation")
+	}
+
+	return NewValidationNode(p.tag[start:p.pos]), nil
+}
+
+func (p *tagParser) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(p.tag[p.pos:], prefix)
+}
+
+func (p *tagParser) errorf(format string, a ...interface{}) *TagSyntaxError {
+	return SyntaxErrorf(format, a...).
+		WithFields(map[string]interface{}{
+			"tag":      p.tag,
+			"position": p.pos,
+		})
+}
@@ -0,0 +1,123 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTag(t *testing.T) {
+	tests := map[string]string{
+		"  email&&  required	": "email&&required",
+		"!len(13)":             "!len(13)",
+		"a&&b||c":              "a&&(b||c)",
+		"(a&&b)||c":            "(a&&b)||c",
+		"!(a||b)&&c":           "!(a||b)&&c",
+		"if(a)then(b)":         "if(a)then(b)",
+		"if(a)then(b)elif(c)then(d)elif(e)then(f)else(g)": "if(a)then(b)elif(c)then(d)elif(e)then(f)else(g)",
+		"if(a)then(b)&&c":                       "if(a)then(b)&&c",
+		"dive(len(3)||!a)":                      "dive(len(3)||!a)",
+		"required&&dive(!a)":                    "required&&dive(!a)",
+		"oneof('New York', 'Los Angeles') && a": "oneof('New York','Los Angeles')&&a",
+		"regex('^(a||b))$') || a":               "regex('^(a||b))$')||a",
+		"regex(`(&& ;`)&&a":                     "regex(`(&& ;`)&&a",
+		`oneof('it\'s (a)', 'b')`:               `oneof('it\'s (a)','b')`,
+	}
+
+	for tag, expected := range tests {
+		t.Run(tag, func(t *testing.T) {
+			node, err := parseTag(tag)
+
+			assert.NoError(t, err)
+			assert.Equal(t, expected, render(node))
+		})
+	}
+}
+
+func TestParseTag_empty(t *testing.T) {
+	node, err := parseTag("  ")
+
+	assert.NoError(t, err)
+	assert.Nil(t, node)
+}
+
+func TestParseTag_failsForInvalidSyntax(t *testing.T) {
+	tags := []string{
+		"len(3",
+		"len(3))",
+		"email&&",
+		"||email",
+		"!",
+		"()",
+		"if(email)",
+		"if(email)then(len(3)",
+		"if(email)then(len(3))else(",
+		"dive(",
+		"oneof('a)",
+		"oneof(`a)",
+		"len(3)#msg(",
+		"len(3)#msg('a)",
+		"len(3)#msg()",
+		"len(3)#msg('a')#msg('b')",
+		"len(3)#message('a')",
+	}
+
+	for _, tag := range tags {
+		t.Run(tag, func(t *testing.T) {
+			_, err := parseTag(tag)
+
+			assert.IsType(t, &TagSyntaxError{}, err)
+		})
+	}
+}
+
+func TestParseTag_messages(t *testing.T) {
+	node, err := parseTag("len(9) #msg('Your username must have 9 characters') || (email && required)#msg(contact.required)")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "len(9)||(email&&required)", render(node))
+	assert.Equal(t, "", node.Message)
+	assert.Equal(t, "len(9)", node.Children[0].SubTag)
+	assert.Equal(t, "Your username must have 9 characters", node.Children[0].Message)
+	assert.Equal(t, "contact.required", node.Children[1].Message)
+
+	node, err = parseTag("!len(0)#msg('must not be empty')")
+	assert.NoError(t, err)
+	assert.Equal(t, NotNode, node.Kind)
+	assert.Equal(t, "must not be empty", node.Message)
+}
+
+// render writes an expression tree in the native syntax with brackets around every nested logical operation
+func render(node *Node) string {
+	switch node.Kind {
+	case AndNode:
+		return operand(node.Children[0]) + "&&" + operand(node.Children[1])
+	case OrNode:
+		return operand(node.Children[0]) + "||" + operand(node.Children[1])
+	case NotNode:
+		return "!" + operand(node.Children[0])
+	case DiveNode:
+		return "dive(" + render(node.Children[0]) + ")"
+	case IfNode:
+		str := "if(" + render(node.Children[0]) + ")then(" + render(node.Children[1]) + ")"
+		if len(node.Children) > 2 {
+			if node.Children[2].Kind == IfNode {
+				return str + "el" + render(node.Children[2])
+			}
+			return str + "else(" + render(node.Children[2]) + ")"
+		}
+		return str
+	}
+
+	return node.SubTag
+}
+
+func operand(node *Node) string {
+	if node.Kind == AndNode || node.Kind == OrNode {
+		return "(" + render(node) + ")"
+	}
+
+	return render(node)
+}
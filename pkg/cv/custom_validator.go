@@ -2,15 +2,67 @@ package cv
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"regexp"
+	"strings"
+	"text/template"
 )
 
 // ValidationContext contains information about the current validation.
 // Will be forwarded to Custom Validators.
-// Contains the current SubTag that is validated.
+// Contains the current SubTag that is validated as well as its parsed name and arguments.
 type ValidationContext struct {
 	SubTag string
+	// Name of the sub tag e.g. len for len(9)
+	Name string
+	// Args are the arguments of the sub tag.
+	// If the custom validator defines parameters they are typed accordingly, otherwise all arguments are of StringType.
+	Args []Arg
+	// FieldName is the name of the validated field
+	FieldName string
+}
+
+// Arg returns the argument at the provided index or an empty argument if it does not exist
+func (vCtx *ValidationContext) Arg(i int) Arg {
+	if i < 0 || i >= len(vCtx.Args) {
+		return Arg{}
+	}
+
+	return vCtx.Args[i]
+}
+
+// ForField returns a validation context containing the name of the provided field.
+// The validation context is copied if its field name has not been set yet.
+func (vCtx *ValidationContext) ForField(f *Field) *ValidationContext {
+	if vCtx != nil && vCtx.FieldName != "" {
+		return vCtx
+	}
+
+	resolved := ValidationContext{}
+	if vCtx != nil {
+		resolved = *vCtx
+	}
+	resolved.FieldName = f.StructField.Name
+
+	return &resolved
+}
+
+// ParseArgs returns a validation context whose arguments are parsed from the SubTag according to the parameters,
+// if they have not been parsed yet e.g. for a validation context created by hand with only a SubTag.
+// Validation contexts that already contain arguments are returned unchanged.
+func (vCtx *ValidationContext) ParseArgs(params ...Param) (*ValidationContext, error) {
+	if vCtx == nil || vCtx.Args != nil {
+		return vCtx, nil
+	}
+
+	parsed, err := newValidationContext(vCtx.SubTag, params)
+	if err != nil {
+		return nil, err
+	}
+	parsed.FieldName = vCtx.FieldName
+
+	return parsed, nil
 }
 
 // Field contains information about the field that is validated.
@@ -19,6 +71,8 @@ type Field struct {
 	Parent      *Field
 	StructField reflect.StructField
 	Value       reflect.Value
+	// Struct is the value of the struct containing the field
+	Struct reflect.Value
 }
 
 // CustomValidationFunc is the type of validation function that needs to be provided in custom validator to be run on struct fields
@@ -29,6 +83,9 @@ type CustomValidatorConfig struct {
 	// Validation will fail if tag is on field of nil ptr
 	// or even if tag is nested on some nil ptr
 	ShouldFailIfFieldOfNilPtr bool
+	// Kinds are the kinds of dereferenced field values the custom validator supports, all kinds are supported if empty.
+	// They are not enforced by the validation but used to detect incompatible tags statically.
+	Kinds []reflect.Kind
 }
 
 // NewCustomValidatorConfig creates a new custom validator configuration
@@ -42,6 +99,27 @@ func (cfg *CustomValidatorConfig) FailForNilValue() *CustomValidatorConfig {
 	return cfg
 }
 
+// ForKinds configures the kinds of dereferenced field values the custom validator supports
+func (cfg *CustomValidatorConfig) ForKinds(kinds ...reflect.Kind) *CustomValidatorConfig {
+	cfg.Kinds = kinds
+	return cfg
+}
+
+// SupportsKind reports whether the custom validator supports dereferenced field values of the provided kind
+func (cfg *CustomValidatorConfig) SupportsKind(kind reflect.Kind) bool {
+	if len(cfg.Kinds) == 0 {
+		return true
+	}
+
+	for _, supported := range cfg.Kinds {
+		if supported == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
 // CustomValidator is used to run validations on struct field tags
 type CustomValidator struct {
 	// ID of the Custom Validator
@@ -53,16 +131,100 @@ type CustomValidator struct {
 	Validate CustomValidationFunc
 	// The configuration for the Custom Validator
 	Config *CustomValidatorConfig
+	// Params is the parameter schema of the sub tag.
+	// If not nil the number and types of the arguments of a sub tag are checked before any value is validated.
+	Params []Param
+	// Schema optionally creates the JSON Schema fragment of a sub tag
+	Schema SchemaFunc
+	// Description optionally describes the requirement of a sub tag in natural language
+	Description *template.Template
 }
 
 // NewCustomValidator creates a new Custom Validator
-func NewCustomValidator(id string, tagRegex *regexp.Regexp, validate CustomValidationFunc, cfg *CustomValidatorConfig) *CustomValidator {
+// Optionally the parameters of the sub tag can be provided which will be parsed into typed arguments.
+// Custom validators without arguments should pass an empty list e.g. []cv.Param{}... to reject any argument,
+// custom validators without parameters accept all arguments as StringType.
+func NewCustomValidator(id string, tagRegex *regexp.Regexp, validate CustomValidationFunc, cfg *CustomValidatorConfig, params ...Param) *CustomValidator {
 	cv := CustomValidator{
 		ID:       id,
 		TagRegex: tagRegex,
 		Validate: validate,
 		Config:   cfg,
+		Params:   params,
 	}
 
 	return &cv
 }
+
+// MatchesSubTag returns whether the TagRegex of the custom validator matches the name of a sub tag.
+// The match must start at the beginning of the sub tag and cover its whole name, so that names within
+// the arguments e.g. oneof('email','phone') or an unanchored regex like email matching emails are no match.
+func (cv *CustomValidator) MatchesSubTag(subTag string) bool {
+	loc := cv.TagRegex.FindStringIndex(subTag)
+	if loc == nil || loc[0] != 0 {
+		return false
+	}
+
+	name := subTag
+	if open := strings.Index(subTag, "("); open >= 0 {
+		name = subTag[:open]
+	}
+
+	return loc[1] >= len(name)
+}
+
+// NewValidationContext parses a sub tag into a validation context according to the parameters of the custom validator.
+// Returns an error if the number or types of the arguments do not match the parameters.
+// If the custom validator has no parameters, i.e. Params is nil, all arguments are accepted as StringType,
+// whereas an empty Params list rejects any argument.
+func (cv *CustomValidator) NewValidationContext(subTag string) (*ValidationContext, error) {
+	return newValidationContext(subTag, cv.Params)
+}
+
+func newValidationContext(subTag string, params []Param) (*ValidationContext, error) {
+	name, rawArgs, err := ParseSubTag(subTag)
+	if err != nil {
+		return nil, err
+	}
+
+	vCtx := &ValidationContext{
+		SubTag: subTag,
+		Name:   name,
+	}
+
+	if params == nil {
+		for _, rawArg := range rawArgs {
+			arg, err := StringParam("").Parse(rawArg)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", name, err)
+			}
+			vCtx.Args = append(vCtx.Args, arg)
+		}
+
+		return vCtx, nil
+	}
+
+	numParams := len(params)
+	variadic := numParams > 0 && params[numParams-1].Variadic
+	if len(rawArgs) != numParams && !(variadic && len(rawArgs) > numParams) {
+		if variadic {
+			return nil, fmt.Errorf("%v expects at least %v parameter(s) but got %v", name, numParams, len(rawArgs))
+		}
+		return nil, fmt.Errorf("%v expects %v parameter(s) but got %v", name, numParams, len(rawArgs))
+	}
+
+	for i, rawArg := range rawArgs {
+		param := params[numParams-1]
+		if i < numParams {
+			param = params[i]
+		}
+
+		arg, err := param.Parse(rawArg)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", name, err)
+		}
+		vCtx.Args = append(vCtx.Args, arg)
+	}
+
+	return vCtx, nil
+}
@@ -0,0 +1,77 @@
+package cv
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// DescriptionData is passed to the description template of a custom validator
+type DescriptionData struct {
+	// Kind is the kind of the dereferenced field e.g. string
+	Kind string
+	// Unit is what the length of the field counts: characters for strings, elements for slices and arrays, entries for maps
+	// and empty for all other kinds
+	Unit string
+	// Name is the name of the sub tag e.g. len for len(9)
+	Name string
+	Args []Arg
+	// Values contains the string representation of the arguments e.g. ["admin", "user"] for oneof(admin,user)
+	Values []string
+}
+
+// descriptionFuncs are the functions which can be used by description templates in addition to the builtin functions
+var descriptionFuncs = template.FuncMap{
+	"join": strings.Join,
+}
+
+// WithDescription sets the text/template describing the requirement of a sub tag of the custom validator in natural language.
+// The description is a phrase in base form that completes the sentence "The field must ...", e.g. "be a valid e-mail address"
+// or "have exactly {{index .Values 0}} {{.Unit}}". The template is executed with DescriptionData and can use the join function.
+// Panics if the template cannot be parsed.
+func (cv *CustomValidator) WithDescription(text string) *CustomValidator {
+	cv.Description = template.Must(template.New(cv.ID).Funcs(descriptionFuncs).Parse(text))
+	return cv
+}
+
+// Explain renders the description of a sub tag for a dereferenced field value of the provided kind.
+// Returns false if the custom validator has no description.
+func (cv *CustomValidator) Explain(kind reflect.Kind, validationCtx *ValidationContext) (string, bool, error) {
+	if cv.Description == nil {
+		return "", false, nil
+	}
+
+	data := DescriptionData{
+		Kind: kind.String(),
+		Unit: LengthUnit(kind),
+		Name: validationCtx.Name,
+		Args: validationCtx.Args,
+	}
+	for _, arg := range validationCtx.Args {
+		data.Values = append(data.Values, arg.String())
+	}
+
+	var buf bytes.Buffer
+	err := cv.Description.Execute(&buf, data)
+	if err != nil {
+		return "", false, err
+	}
+
+	return buf.String(), true, nil
+}
+
+// LengthUnit returns what the length of a value of the provided kind counts: characters for strings,
+// elements for slices and arrays, entries for maps and an empty string for all other kinds
+func LengthUnit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "characters"
+	case reflect.Slice, reflect.Array:
+		return "elements"
+	case reflect.Map:
+		return "entries"
+	}
+
+	return ""
+}
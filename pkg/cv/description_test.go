@@ -0,0 +1,44 @@
+package cv
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomValidator_Explain(t *testing.T) {
+	customValidator := NewCustomValidator("between", regexp.MustCompile(`^between\(.*\)$`), func(ctx context.Context, f *Field, vCtx *ValidationContext) error {
+		return nil
+	}, NewCustomValidatorConfig(), IntParam("min"), IntParam("max")).
+		WithDescription(`{{if .Unit}}have between {{join .Values " and "}} {{.Unit}}{{else}}be between {{index .Values 0}} and {{index .Values 1}}{{end}}`)
+
+	vCtx, err := customValidator.NewValidationContext("between(1,5)")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	description, ok, err := customValidator.Explain(reflect.String, vCtx)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "have between 1 and 5 characters", description)
+
+	description, ok, err = customValidator.Explain(reflect.Int, vCtx)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "be between 1 and 5", description)
+
+	customValidator.Description = nil
+	_, ok, err = customValidator.Explain(reflect.Int, vCtx)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestCustomValidator_WithDescription_panicsForInvalidTemplate(t *testing.T) {
+	customValidator := NewCustomValidator("invalid", regexp.MustCompile("^invalid$"), nil, NewCustomValidatorConfig())
+	assert.Panics(t, func() {
+		customValidator.WithDescription("{{if}}")
+	})
+}
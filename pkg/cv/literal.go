@@ -0,0 +1,67 @@
+package cv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IsQuote reports whether the character starts a string literal.
+// String literals are either enclosed in single quotes e.g. 'New York' or in backticks e.g. `[a-z]+`.
+func IsQuote(c byte) bool {
+	return c == '\'' || c == '`'
+}
+
+// ScanLiteral returns the index after the closing quote of the string literal starting at s[start].
+// Within single quotes a quote or backslash can be escaped by a backslash, backtick literals do not support escape sequences.
+func ScanLiteral(s string, start int) (int, error) {
+	quote := s[start]
+	for i := start + 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quote == '\'':
+			i++
+		case s[i] == quote:
+			return i + 1, nil
+		}
+	}
+
+	return 0, fmt.Errorf("string literal %v is not terminated", s[start:])
+}
+
+// Unquote removes the quotes of a string literal and resolves its escape sequences \' and \\.
+// Other backslashes are preserved e.g. to be able to write regular expressions like '\d+'.
+// A string that is not quoted is returned as it is.
+func Unquote(literal string) (string, error) {
+	if literal == "" || !IsQuote(literal[0]) {
+		return literal, nil
+	}
+
+	end, err := ScanLiteral(literal, 0)
+	if err != nil {
+		return "", err
+	}
+
+	if end != len(literal) {
+		return "", fmt.Errorf("unexpected characters after string literal %v", literal[:end])
+	}
+
+	inner := literal[1 : len(literal)-1]
+	if literal[0] == '`' {
+		return inner, nil
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(inner); i++ {
+		if inner[i] == '\\' && i+1 < len(inner) && (inner[i+1] == '\'' || inner[i+1] == '\\') {
+			i++
+		}
+		sb.WriteByte(inner[i])
+	}
+
+	return sb.String(), nil
+}
+
+// Quote creates a string literal enclosed in single quotes that is unquoted to the provided string by Unquote
+func Quote(str string) string {
+	str = strings.ReplaceAll(str, `\`, `\\`)
+	return fmt.Sprintf("'%v'", strings.ReplaceAll(str, "'", `\'`))
+}
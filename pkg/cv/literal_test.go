@@ -0,0 +1,49 @@
+package cv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnquote(t *testing.T) {
+	tests := map[string]string{
+		"abc":              "abc",
+		"'New York'":       "New York",
+		`'it\'s'`:          "it's",
+		`'back\\slash'`:    `back\slash`,
+		`'\d+'`:            `\d+`,
+		"`raw \\' string`": `raw \' string`,
+		"''":               "",
+	}
+
+	for literal, expected := range tests {
+		t.Run(literal, func(t *testing.T) {
+			unquoted, err := Unquote(literal)
+
+			assert.NoError(t, err)
+			assert.Equal(t, expected, unquoted)
+		})
+	}
+}
+
+func TestUnquote_failsForInvalidLiterals(t *testing.T) {
+	for _, literal := range []string{"'abc", `'abc\'`, "'a'b", "`abc"} {
+		t.Run(literal, func(t *testing.T) {
+			_, err := Unquote(literal)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestQuote(t *testing.T) {
+	for _, str := range []string{"abc", "New York", "it's", `\d+\'`, ""} {
+		t.Run(str, func(t *testing.T) {
+			unquoted, err := Unquote(Quote(str))
+
+			assert.NoError(t, err)
+			assert.Equal(t, str, unquoted)
+		})
+	}
+}
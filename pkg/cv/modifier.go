@@ -0,0 +1,20 @@
+package cv
+
+import "context"
+
+// ModifierFunc is the type of function that needs to be provided in a modifier to modify the values of string fields
+type ModifierFunc func(ctx context.Context, value string) string
+
+// Modifier modifies the values of string fields whose normalize tags contain its ID e.g. `normalize:"trim,lower"`
+type Modifier struct {
+	ID     string
+	Modify ModifierFunc
+}
+
+// NewModifier creates a new modifier that is referenced by its ID in normalize tags
+func NewModifier(id string, modify ModifierFunc) *Modifier {
+	return &Modifier{
+		ID:     id,
+		Modify: modify,
+	}
+}
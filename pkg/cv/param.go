@@ -0,0 +1,216 @@
+package cv
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParamType defines the type of a parameter of a custom validator
+type ParamType string
+
+const (
+	// IntType parameters are parsed by strconv.Atoi e.g. len(9)
+	IntType ParamType = "int"
+	// FloatType parameters are parsed by strconv.ParseFloat e.g. min(0.5)
+	FloatType ParamType = "float"
+	// StringType parameters are passed as they are written in the tag or unquoted if they are string literals e.g. oneof('New York')
+	StringType ParamType = "string"
+	// DurationType parameters are parsed by time.ParseDuration e.g. max-age(24h)
+	DurationType ParamType = "duration"
+	// FieldType parameters reference another field of the struct containing the validated field e.g. eq-field(Password)
+	FieldType ParamType = "field"
+	// RegexType parameters are compiled by regexp.Compile e.g. regex('^[a-z]+$')
+	RegexType ParamType = "regex"
+)
+
+var fieldPathRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+// Param describes a parameter of a custom validator
+type Param struct {
+	Name string
+	Type ParamType
+	// Variadic parameters accept one or more arguments and may only be used as last parameter
+	Variadic bool
+}
+
+// Variadic turns the provided parameter into a variadic parameter e.g. Variadic(StringParam("values")) for oneof('a','b')
+func Variadic(p Param) Param {
+	p.Variadic = true
+	return p
+}
+
+// IntParam creates a new parameter of type int
+func IntParam(name string) Param {
+	return Param{Name: name, Type: IntType}
+}
+
+// FloatParam creates a new parameter of type float64
+func FloatParam(name string) Param {
+	return Param{Name: name, Type: FloatType}
+}
+
+// StringParam creates a new parameter of type string
+func StringParam(name string) Param {
+	return Param{Name: name, Type: StringType}
+}
+
+// DurationParam creates a new parameter of type time.Duration
+func DurationParam(name string) Param {
+	return Param{Name: name, Type: DurationType}
+}
+
+// FieldParam creates a new parameter referencing another field by its path e.g. Password or Address.Street
+func FieldParam(name string) Param {
+	return Param{Name: name, Type: FieldType}
+}
+
+// RegexParam creates a new parameter of type *regexp.Regexp
+func RegexParam(name string) Param {
+	return Param{Name: name, Type: RegexType}
+}
+
+// Parse parses the raw argument of a sub tag according to the type of the parameter.
+// Quoted string literals are unquoted before they are parsed.
+func (p Param) Parse(raw string) (Arg, error) {
+	arg := Arg{
+		Type: p.Type,
+		Raw:  raw,
+	}
+
+	unquoted, err := Unquote(raw)
+	if err != nil {
+		return Arg{}, fmt.Errorf("parameter %v cannot be parsed from %v: %w", p.Name, raw, err)
+	}
+
+	switch p.Type {
+	case IntType:
+		arg.Value, err = strconv.Atoi(unquoted)
+	case FloatType:
+		arg.Value, err = strconv.ParseFloat(unquoted, 64)
+	case DurationType:
+		arg.Value, err = time.ParseDuration(unquoted)
+	case RegexType:
+		arg.Value, err = regexp.Compile(unquoted)
+	case FieldType:
+		if !fieldPathRegex.MatchString(unquoted) {
+			err = fmt.Errorf("invalid field path")
+		}
+		arg.Value = FieldRef{Path: unquoted}
+	default:
+		arg.Type = StringType
+		arg.Value = unquoted
+	}
+
+	if err != nil {
+		return Arg{}, fmt.Errorf("parameter %v of type %v cannot be parsed from %q: %w", p.Name, p.Type, raw, err)
+	}
+
+	return arg, nil
+}
+
+// FieldRef is the value of an argument of type FieldType before it is resolved during the validation
+type FieldRef struct {
+	// Path of the referenced field relative to the struct containing the validated field
+	Path string
+}
+
+// Arg is a typed argument of a sub tag
+type Arg struct {
+	Type ParamType
+	// Raw is the argument as written in the tag
+	Raw string
+	// Value is either an int, float64, string, time.Duration, *regexp.Regexp
+	// or for arguments of type FieldType the reflect.Value of the referenced field
+	Value interface{}
+}
+
+// Int returns the value of an argument of type IntType or 0 otherwise
+func (arg Arg) Int() int {
+	i, _ := arg.Value.(int)
+	return i
+}
+
+// Float returns the value of an argument of type FloatType or 0 otherwise
+func (arg Arg) Float() float64 {
+	f, _ := arg.Value.(float64)
+	return f
+}
+
+// String returns the value of an argument of type StringType or its raw value otherwise
+func (arg Arg) String() string {
+	if s, ok := arg.Value.(string); ok {
+		return s
+	}
+	return arg.Raw
+}
+
+// Duration returns the value of an argument of type DurationType or 0 otherwise
+func (arg Arg) Duration() time.Duration {
+	d, _ := arg.Value.(time.Duration)
+	return d
+}
+
+// Regexp returns the value of an argument of type RegexType or nil otherwise
+func (arg Arg) Regexp() *regexp.Regexp {
+	r, _ := arg.Value.(*regexp.Regexp)
+	return r
+}
+
+// Field returns the value of the field referenced by an argument of type FieldType or an invalid reflect.Value otherwise
+func (arg Arg) Field() reflect.Value {
+	v, _ := arg.Value.(reflect.Value)
+	return v
+}
+
+// ParseSubTag splits a sub tag of the form name(arg1,arg2,...) into its name and raw arguments.
+// A sub tag without brackets has no arguments.
+// Commas and brackets within string literals e.g. oneof('a,b','c)') do not separate arguments.
+func ParseSubTag(subTag string) (string, []string, error) {
+	open := strings.Index(subTag, "(")
+	if open < 0 {
+		return subTag, nil, nil
+	}
+
+	if !strings.HasSuffix(subTag, ")") {
+		return "", nil, fmt.Errorf("sub tag %v must end with a closing bracket", subTag)
+	}
+
+	name := subTag[:open]
+	inner := subTag[open+1 : len(subTag)-1]
+	if inner == "" {
+		return name, nil, nil
+	}
+
+	var args []string
+	numOpenBraces := 0
+	start := 0
+	for i := 0; i < len(inner); i++ {
+		if IsQuote(inner[i]) {
+			end, err := ScanLiteral(inner, i)
+			if err != nil {
+				return "", nil, err
+			}
+			i = end - 1
+			continue
+		}
+
+		switch inner[i] {
+		case '(':
+			numOpenBraces++
+		case ')':
+			numOpenBraces--
+		case ',':
+			if numOpenBraces == 0 {
+				args = append(args, inner[start:i])
+				start = i + 1
+			}
+		}
+	}
+	args = append(args, inner[start:])
+
+	return name, args, nil
+}
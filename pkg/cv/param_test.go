@@ -0,0 +1,106 @@
+package cv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSubTag(t *testing.T) {
+	tests := map[string]struct {
+		name string
+		args []string
+	}{
+		"email":          {name: "email"},
+		"len()":          {name: "len"},
+		"len(9)":         {name: "len", args: []string{"9"}},
+		"between(1,5)":   {name: "between", args: []string{"1", "5"}},
+		"nested(f(1,2))": {name: "nested", args: []string{"f(1,2)"}},
+	}
+
+	for subTag, expected := range tests {
+		t.Run(subTag, func(t *testing.T) {
+			name, args, err := ParseSubTag(subTag)
+
+			assert.NoError(t, err)
+			assert.Equal(t, expected.name, name)
+			assert.Equal(t, expected.args, args)
+		})
+	}
+}
+
+func TestCustomValidator_NewValidationContext(t *testing.T) {
+	customValidator := NewCustomValidator("typed", nil, nil, NewCustomValidatorConfig(),
+		IntParam("int"), FloatParam("float"), StringParam("string"), DurationParam("duration"), FieldParam("field"))
+
+	vCtx, err := customValidator.NewValidationContext("typed(1,2.5,abc,1h30m,Nested.Field)")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "typed", vCtx.Name)
+	assert.Equal(t, 1, vCtx.Arg(0).Int())
+	assert.Equal(t, 2.5, vCtx.Arg(1).Float())
+	assert.Equal(t, "abc", vCtx.Arg(2).String())
+	assert.Equal(t, 90*time.Minute, vCtx.Arg(3).Duration())
+	assert.Equal(t, FieldRef{Path: "Nested.Field"}, vCtx.Arg(4).Value)
+	assert.Equal(t, Arg{}, vCtx.Arg(5))
+}
+
+func TestCustomValidator_NewValidationContext_withoutParams(t *testing.T) {
+	customValidator := NewCustomValidator("untyped", nil, nil, NewCustomValidatorConfig())
+
+	vCtx, err := customValidator.NewValidationContext("untyped(1,a)")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Arg{{Type: StringType, Raw: "1", Value: "1"}, {Type: StringType, Raw: "a", Value: "a"}}, vCtx.Args)
+}
+
+func TestCustomValidator_NewValidationContext_withEmptyParams(t *testing.T) {
+	customValidator := NewCustomValidator("flag", nil, nil, NewCustomValidatorConfig(), []Param{}...)
+
+	vCtx, err := customValidator.NewValidationContext("flag")
+	assert.NoError(t, err)
+	assert.Empty(t, vCtx.Args)
+
+	_, err = customValidator.NewValidationContext("flag(1)")
+	assert.EqualError(t, err, "flag expects 0 parameter(s) but got 1")
+}
+
+func TestValidationContext_ParseArgs(t *testing.T) {
+	vCtx, err := (&ValidationContext{SubTag: "typed(1,1h)", FieldName: "Field"}).ParseArgs(IntParam("int"), DurationParam("duration"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "typed", vCtx.Name)
+	assert.Equal(t, "Field", vCtx.FieldName)
+	assert.Equal(t, 1, vCtx.Arg(0).Int())
+	assert.Equal(t, time.Hour, vCtx.Arg(1).Duration())
+
+	// arguments that have already been parsed are kept
+	parsed, err := vCtx.ParseArgs(StringParam("string"))
+	assert.NoError(t, err)
+	assert.Same(t, vCtx, parsed)
+
+	_, err = (&ValidationContext{SubTag: "typed(a)"}).ParseArgs(IntParam("int"))
+	assert.Error(t, err)
+}
+
+func TestCustomValidator_NewValidationContext_failsForInvalidArguments(t *testing.T) {
+	customValidator := NewCustomValidator("typed", nil, nil, NewCustomValidatorConfig(), IntParam("int"), DurationParam("duration"))
+
+	subTags := []string{
+		"typed",
+		"typed(1)",
+		"typed(1,1h,1)",
+		"typed(a,1h)",
+		"typed(1,1)",
+		"typed(1,1h",
+	}
+
+	for _, subTag := range subTags {
+		t.Run(subTag, func(t *testing.T) {
+			_, err := customValidator.NewValidationContext(subTag)
+
+			assert.Error(t, err)
+		})
+	}
+}
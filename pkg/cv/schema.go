@@ -0,0 +1,23 @@
+package cv
+
+import "reflect"
+
+// SchemaFragment is the JSON Schema of a single sub tag e.g. {"minLength": 9, "maxLength": 9} for len(9) on a string field
+type SchemaFragment struct {
+	// Keywords are JSON Schema keywords that are added to the schema of the field
+	Keywords map[string]interface{}
+	// Required marks the field as required property of the object containing it
+	Required bool
+}
+
+// SchemaFunc creates the JSON Schema fragment of a sub tag for a dereferenced field value of the provided kind.
+// Returns nil if the validation cannot be expressed by JSON Schema.
+// For pointer fields it is additionally called with reflect.Ptr, whose fragment only decides whether the property is required.
+type SchemaFunc func(kind reflect.Kind, validationCtx *ValidationContext) *SchemaFragment
+
+// WithSchema sets the function contributing JSON Schema fragments for the sub tags of the custom validator.
+// Sub tags of custom validators without schema function are omitted from generated schemas.
+func (cv *CustomValidator) WithSchema(schema SchemaFunc) *CustomValidator {
+	cv.Schema = schema
+	return cv
+}
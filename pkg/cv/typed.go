@@ -0,0 +1,147 @@
+package cv
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+)
+
+// TypedValidationFunc is the type of validation function of typed custom validators.
+// The value has already been dereferenced and converted to T.
+type TypedValidationFunc[T any] func(ctx context.Context, value T, validationCtx *ValidationContext) error
+
+// TypeError is returned by typed custom validators if a field is nil or cannot be converted to the type of the validator
+type TypeError struct {
+	ValidatorID string
+	FieldName   string
+	// Kind of the dereferenced field value or reflect.Invalid if the field is nil
+	Kind reflect.Kind
+	// Type the field should have been converted to
+	Type reflect.Type
+}
+
+// Error returns the error message string
+// Implements error interface
+func (err *TypeError) Error() string {
+	if err.Kind == reflect.Invalid {
+		return fmt.Sprintf("%v field %v is nil", err.ValidatorID, err.FieldName)
+	}
+	return fmt.Sprintf("%v field %v is of kind %v and cannot be converted to %v", err.ValidatorID, err.FieldName, err.Kind, err.Type)
+}
+
+// NewTyped creates a new Custom Validator whose validation function receives the field value converted to T.
+// Pointers and interfaces are dereferenced, a nil value or a value of another kind than T results in a TypeError.
+// If no kinds are configured, the kind of T is configured as the only supported kind of a copy of the configuration,
+// so that a configuration can be shared by multiple custom validators. A nil configuration defaults to NewCustomValidatorConfig().
+// Usage:
+//
+//	cv.NewTyped("lowercase", regexp.MustCompile("lowercase"), func(ctx context.Context, s string, vCtx *cv.ValidationContext) error {
+//		...
+//	}, cv.NewCustomValidatorConfig())
+func NewTyped[T any](id string, tagRegex *regexp.Regexp, validate TypedValidationFunc[T], cfg *CustomValidatorConfig, params ...Param) *CustomValidator {
+	if cfg == nil {
+		cfg = NewCustomValidatorConfig()
+	} else {
+		copied := *cfg
+		cfg = &copied
+	}
+
+	kind := reflect.TypeOf((*T)(nil)).Elem().Kind()
+	if len(cfg.Kinds) == 0 && kind != reflect.Interface {
+		cfg.ForKinds(kind)
+	}
+
+	return NewCustomValidator(id, tagRegex, Typed(id, validate), cfg, params...)
+}
+
+// Typed converts a typed validation function into a custom validation function
+func Typed[T any](id string, validate TypedValidationFunc[T]) CustomValidationFunc {
+	return func(ctx context.Context, f *Field, validationCtx *ValidationContext) error {
+		value, err := ValueOf[T](f.Value)
+		if err != nil {
+			err.ValidatorID = id
+			err.FieldName = f.StructField.Name
+			return err
+		}
+
+		return validate(ctx, value, validationCtx.ForField(f))
+	}
+}
+
+// ValueOf dereferences the provided value and converts it to T.
+// Values of named types are converted to T if they share the same kind e.g. type CustomString string.
+// Returns a TypeError without validator and field information if the value is nil or cannot be converted.
+func ValueOf[T any](value reflect.Value) (T, *TypeError) {
+	var zero T
+	target := reflect.TypeOf((*T)(nil)).Elem()
+
+	value, ok := Deref(value)
+	if !ok {
+		return zero, &TypeError{Kind: reflect.Invalid, Type: target}
+	}
+
+	if !value.CanInterface() {
+		// values of unexported fields cannot be accessed by Interface(), so basic kinds are copied
+		kind := value.Kind()
+		value = copyBasicValue(value)
+		if !value.IsValid() {
+			return zero, &TypeError{Kind: kind, Type: target}
+		}
+	}
+
+	if value.Type().AssignableTo(target) {
+		return value.Interface().(T), nil
+	}
+
+	if value.Kind() == target.Kind() && value.Type().ConvertibleTo(target) {
+		return value.Convert(target).Interface().(T), nil
+	}
+
+	return zero, &TypeError{Kind: value.Kind(), Type: target}
+}
+
+// Deref dereferences all interfaces and pointers of a value.
+// Returns false if the value or any dereferenced value is nil.
+func Deref(value reflect.Value) (reflect.Value, bool) {
+	if !value.IsValid() {
+		return value, false
+	}
+
+	kind := value.Kind()
+	for kind == reflect.Interface || kind == reflect.Ptr {
+		if value.IsNil() {
+			return value, false
+		}
+
+		value = value.Elem()
+		kind = value.Kind()
+	}
+
+	return value, true
+}
+
+// copyBasicValue copies a value of a basic kind into a new value of the same type.
+// Returns an invalid reflect.Value for all other kinds.
+func copyBasicValue(value reflect.Value) reflect.Value {
+	var basic reflect.Value
+
+	switch value.Kind() {
+	case reflect.String:
+		basic = reflect.ValueOf(value.String())
+	case reflect.Bool:
+		basic = reflect.ValueOf(value.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		basic = reflect.ValueOf(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		basic = reflect.ValueOf(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		basic = reflect.ValueOf(value.Float())
+	case reflect.Complex64, reflect.Complex128:
+		basic = reflect.ValueOf(value.Complex())
+	default:
+		return reflect.Value{}
+	}
+
+	return basic.Convert(value.Type())
+}
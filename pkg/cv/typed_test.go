@@ -0,0 +1,131 @@
+package cv
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type customString string
+
+type unexportedStruct struct {
+	value customString
+}
+
+func TestValueOf(t *testing.T) {
+	str := "value"
+	var iface interface{} = &str
+
+	values := map[string]reflect.Value{
+		"string":           reflect.ValueOf("value"),
+		"pointer":          reflect.ValueOf(&str),
+		"interface":        reflect.ValueOf(&iface).Elem(),
+		"custom type":      reflect.ValueOf(customString("value")),
+		"unexported field": reflect.ValueOf(unexportedStruct{value: "value"}).Field(0),
+	}
+
+	for key, val := range values {
+		t.Run(key, func(t *testing.T) {
+			value, err := ValueOf[string](val)
+
+			assert.Nil(t, err)
+			assert.Equal(t, "value", value)
+		})
+	}
+}
+
+func TestValueOf_failsForInvalidValues(t *testing.T) {
+	var nilPtr *string
+
+	values := map[string]reflect.Value{
+		"nil":      reflect.ValueOf(nilPtr),
+		"invalid":  {},
+		"int":      reflect.ValueOf(1),
+		"struct":   reflect.ValueOf(unexportedStruct{}),
+		"duration": reflect.ValueOf(time.Second),
+	}
+
+	for key, val := range values {
+		t.Run(key, func(t *testing.T) {
+			_, err := ValueOf[string](val)
+
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestTyped(t *testing.T) {
+	var received time.Duration
+	validate := Typed("duration", func(ctx context.Context, d time.Duration, vCtx *ValidationContext) error {
+		received = d
+		assert.Equal(t, "Timeout", vCtx.FieldName)
+		return nil
+	})
+
+	f := &Field{
+		StructField: reflect.StructField{Name: "Timeout"},
+		Value:       reflect.ValueOf(time.Minute),
+	}
+
+	err := validate(context.Background(), f, &ValidationContext{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, received)
+}
+
+func TestTyped_failsForNil(t *testing.T) {
+	validate := Typed("duration", func(ctx context.Context, d time.Duration, vCtx *ValidationContext) error {
+		return nil
+	})
+
+	var nilPtr *time.Duration
+	f := &Field{
+		StructField: reflect.StructField{Name: "Timeout"},
+		Value:       reflect.ValueOf(nilPtr),
+	}
+
+	err := validate(context.Background(), f, &ValidationContext{})
+
+	assert.EqualError(t, err, "duration field Timeout is nil")
+}
+
+func TestNewTyped_configuresKind(t *testing.T) {
+	validate := func(ctx context.Context, s string, vCtx *ValidationContext) error {
+		return nil
+	}
+
+	customValidator := NewTyped("lowercase", nil, validate, NewCustomValidatorConfig())
+	assert.Equal(t, []reflect.Kind{reflect.String}, customValidator.Config.Kinds)
+	assert.True(t, customValidator.Config.SupportsKind(reflect.String))
+	assert.False(t, customValidator.Config.SupportsKind(reflect.Int))
+
+	customValidator = NewTyped("lowercase", nil, validate, NewCustomValidatorConfig().ForKinds(reflect.String, reflect.Slice))
+	assert.Equal(t, []reflect.Kind{reflect.String, reflect.Slice}, customValidator.Config.Kinds)
+
+	assert.True(t, NewCustomValidatorConfig().SupportsKind(reflect.Int))
+}
+
+func TestNewTyped_copiesConfig(t *testing.T) {
+	validateString := func(ctx context.Context, s string, vCtx *ValidationContext) error {
+		return nil
+	}
+	validateInt := func(ctx context.Context, i int, vCtx *ValidationContext) error {
+		return nil
+	}
+
+	cfg := NewCustomValidatorConfig().FailForNilValue()
+	lowercase := NewTyped("lowercase", nil, validateString, cfg)
+	positive := NewTyped("positive", nil, validateInt, cfg)
+
+	assert.Empty(t, cfg.Kinds)
+	assert.Equal(t, []reflect.Kind{reflect.String}, lowercase.Config.Kinds)
+	assert.Equal(t, []reflect.Kind{reflect.Int}, positive.Config.Kinds)
+	assert.True(t, positive.Config.ShouldFailIfFieldOfNilPtr)
+
+	customValidator := NewTyped("lowercase", nil, validateString, nil)
+	assert.Equal(t, []reflect.Kind{reflect.String}, customValidator.Config.Kinds)
+	assert.False(t, customValidator.Config.ShouldFailIfFieldOfNilPtr)
+}
@@ -0,0 +1,244 @@
+package validator
+
+import (
+	"reflect"
+	"sync"
+)
+
+// IgnoreTag is the value of a struct field tag that excludes the field and its nested structs from the validation e.g. `validator:"-"`
+const IgnoreTag = "-"
+
+// RegisterOpaqueType registers the type of the provided value as opaque, so that the validation never descends into its fields
+// e.g. for generated protobuf messages. The tags of fields whose type is opaque are still validated.
+// Usage:
+//
+//	validator.RegisterOpaqueType(pb.User{})
+func (v *Validator) RegisterOpaqueType(i interface{}) {
+	if v.opaqueTypes == nil {
+		v.opaqueTypes = map[reflect.Type]bool{}
+	}
+
+	v.opaqueTypes[getUnderlyingType(reflect.TypeOf(i))] = true
+	v.plans.reset()
+}
+
+func (v *Validator) isOpaque(rType reflect.Type) bool {
+	return v.opaqueTypes[rType]
+}
+
+// typePlan defines which fields of a struct type are visited by the validation
+type typePlan struct {
+	fields []fieldPlan
+}
+
+type fieldPlan struct {
+	// skip is true if the field neither has tags nor nested structs containing tags
+	skip bool
+	// descend is true if the nested struct of the field is validated
+	descend bool
+	// node is the compiled expression tree of the tags of the field if compiled is true.
+	// Tags that cannot be compiled are not cached, so that their errors are created for every validation.
+	node     *Node
+	compiled bool
+}
+
+// planCache caches the plans of the struct types validated by a validator, which are computed for its tag keys.
+// It also caches the compiled rules defined in code by the struct type and expression of the rules.
+// The cache is reset whenever custom validators, aliases, macros or opaque types are registered.
+type planCache struct {
+	mu      sync.RWMutex
+	tagKeys []TagKey
+	plans   map[reflect.Type]*typePlan
+	rules   map[ruleKey]*Node
+}
+
+// ruleKey identifies the compiled rules of a field by the struct type of the field and the expression of the rules
+type ruleKey struct {
+	structType reflect.Type
+	expression string
+}
+
+func newPlanCache() *planCache {
+	return &planCache{plans: map[reflect.Type]*typePlan{}, rules: map[ruleKey]*Node{}}
+}
+
+func (cache *planCache) get(structType reflect.Type, tagKeys []TagKey) (*typePlan, bool) {
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+
+	if !equalTagKeys(cache.tagKeys, tagKeys) {
+		return nil, false
+	}
+
+	plan, ok := cache.plans[structType]
+	return plan, ok
+}
+
+func (cache *planCache) set(structType reflect.Type, tagKeys []TagKey, plan *typePlan) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	// the tag keys of the validator have been changed
+	if !equalTagKeys(cache.tagKeys, tagKeys) {
+		cache.tagKeys = tagKeys
+		cache.plans = map[reflect.Type]*typePlan{}
+	}
+
+	cache.plans[structType] = plan
+}
+
+func (cache *planCache) reset() {
+	if cache == nil {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.plans = map[reflect.Type]*typePlan{}
+	cache.rules = map[ruleKey]*Node{}
+}
+
+func (cache *planCache) getRules(key ruleKey) (*Node, bool) {
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+
+	node, ok := cache.rules[key]
+	return node, ok
+}
+
+func (cache *planCache) setRules(key ruleKey, node *Node) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.rules[key] = node
+}
+
+func equalTagKeys(a, b []TagKey) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// plan returns the plan of a struct type. Validators created by NewValidator cache the plans, which skip all fields
+// that neither have tags nor nested structs containing tags and contain the compiled tags of the fields.
+// All other validators only skip ignored fields and opaque types and compile the tags for every validation.
+// The plans only depend on the tags of a type: rules defined in code by ValidateStruct override the plan for their fields
+// and nested structs, and Hooks only observe fields with tags or rules, so skipped fields are never observed.
+func (v *Validator) plan(structType reflect.Type) *typePlan {
+	if v.plans == nil {
+		return v.computePlan(structType, false)
+	}
+
+	tagKeys := v.tagKeys()
+	if plan, ok := v.plans.get(structType, tagKeys); ok {
+		return plan
+	}
+
+	plan := v.computePlan(structType, true)
+	v.plans.set(structType, tagKeys, plan)
+
+	return plan
+}
+
+// computePlan computes the plan of a struct type. Fields without rules are only skipped if the plan is precise.
+func (v *Validator) computePlan(structType reflect.Type, precise bool) *typePlan {
+	plan := &typePlan{fields: make([]fieldPlan, structType.NumField())}
+
+	for i := range plan.fields {
+		structField := structType.Field(i)
+		if v.isIgnored(structField) {
+			plan.fields[i] = fieldPlan{skip: true}
+			continue
+		}
+
+		nestedType := getUnderlyingType(structField.Type)
+		descend := nestedType.Kind() == reflect.Interface ||
+			nestedType.Kind() == reflect.Struct && !v.isOpaque(nestedType)
+
+		if precise && descend && nestedType.Kind() == reflect.Struct {
+			descend = v.containsRules(nestedType, map[reflect.Type]bool{})
+		}
+
+		plan.fields[i] = fieldPlan{
+			skip:    precise && !descend && !v.isTagged(structField),
+			descend: descend,
+		}
+
+		if precise && !plan.fields[i].skip {
+			node, err := v.compileField(structType, structField)
+			plan.fields[i].node, plan.fields[i].compiled = node, err == nil
+		}
+	}
+
+	return plan
+}
+
+// compiledField returns the compiled tags of a struct field from the plan of its struct type if the plan contains them,
+// otherwise the tags are compiled
+func (v *Validator) compiledField(structType reflect.Type, structField reflect.StructField) (*Node, error) {
+	if v.plans != nil && len(structField.Index) == 1 {
+		fieldPlan := v.plan(structType).fields[structField.Index[0]]
+		if fieldPlan.compiled {
+			return fieldPlan.node, nil
+		}
+	}
+
+	return v.compileField(structType, structField)
+}
+
+// containsRules returns whether any field of a struct type or its nested structs has tags while visited contains the struct types
+// that have been searched already. Interfaces are assumed to contain rules since their dynamic types are unknown.
+func (v *Validator) containsRules(structType reflect.Type, visited map[reflect.Type]bool) bool {
+	visited[structType] = true
+
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		if v.isIgnored(structField) {
+			continue
+		}
+
+		if v.isTagged(structField) {
+			return true
+		}
+
+		nestedType := getUnderlyingType(structField.Type)
+		if nestedType.Kind() == reflect.Interface {
+			return true
+		}
+
+		if nestedType.Kind() != reflect.Struct || v.isOpaque(nestedType) || visited[nestedType] {
+			continue
+		}
+
+		if v.containsRules(nestedType, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isTagged returns whether a struct field has any of the tag keys of the validator
+func (v *Validator) isTagged(structField reflect.StructField) bool {
+	return v.HasTagKey(structField.Tag)
+}
+
+// isIgnored returns whether a struct field is excluded from the validation by any of the tag keys of the validator
+func (v *Validator) isIgnored(structField reflect.StructField) bool {
+	for _, tagKey := range v.tagKeys() {
+		if structField.Tag.Get(tagKey.Name) == IgnoreTag {
+			return true
+		}
+	}
+
+	return false
+}
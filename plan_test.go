@@ -0,0 +1,192 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator/dv"
+)
+
+type PlannedUser struct {
+	Name     string `validator:"len(3)"`
+	Created  time.Time
+	Untagged PlannedUntagged
+	Address  *PlannedAddress
+	Ignored  PlannedAddress `validator:"-"`
+	Opaque   PlannedOpaque  `validator:"non-nil"`
+	Any      interface{}
+	Parent   *PlannedParent
+	Note     string
+}
+
+type PlannedUntagged struct {
+	Value string
+	Time  time.Time
+}
+
+type PlannedAddress struct {
+	Street string `validator:"required"`
+}
+
+type PlannedOpaque struct {
+	ID string `validator:"len(36)"`
+}
+
+// PlannedParent only contains rules by a cycle to PlannedChild
+type PlannedParent struct {
+	Child *PlannedChild
+}
+
+type PlannedChild struct {
+	Parent *PlannedParent
+	Name   string `validator:"len(3)"`
+}
+
+func TestValidator_plan(t *testing.T) {
+	validator := NewValidator()
+	validator.RegisterOpaqueType(PlannedOpaque{})
+
+	plan := validator.plan(reflect.TypeOf(PlannedUser{}))
+	visits := make([]fieldPlan, len(plan.fields))
+	for i, fieldPlan := range plan.fields {
+		visits[i].skip, visits[i].descend = fieldPlan.skip, fieldPlan.descend
+	}
+	assert.Equal(t, []fieldPlan{
+		{skip: false, descend: false},
+		{skip: true, descend: false},
+		{skip: true, descend: false},
+		{skip: false, descend: true},
+		{skip: true, descend: false},
+		{skip: false, descend: false},
+		{skip: false, descend: true},
+		{skip: false, descend: true},
+		{skip: true, descend: false},
+	}, visits)
+	assert.Same(t, plan, validator.plan(reflect.TypeOf(PlannedUser{})))
+
+	// the plans are computed again for other tag keys
+	validator.SetTagKeys(NativeTagKey("check"))
+	plan = validator.plan(reflect.TypeOf(PlannedUser{}))
+	assert.True(t, plan.fields[0].skip)
+	assert.True(t, plan.fields[3].skip)
+	assert.False(t, plan.fields[6].skip)
+
+	// validators that have not been created by NewValidator do not skip fields without rules
+	plan = (&Validator{}).plan(reflect.TypeOf(PlannedUser{}))
+	assert.False(t, plan.fields[2].skip)
+	assert.True(t, plan.fields[2].descend)
+	assert.True(t, plan.fields[4].skip)
+}
+
+func TestValidator_plan_cachesCompiledTags(t *testing.T) {
+	type Aliased struct {
+		Name  string `validator:"name"`
+		Email string `validator:"len(abc)"`
+	}
+	validator := NewValidator()
+	assert.NoError(t, validator.RegisterAlias("name", "len(3)"))
+
+	structType := reflect.TypeOf(Aliased{})
+	plan := validator.plan(structType)
+	if assert.True(t, plan.fields[0].compiled) {
+		node, err := validator.compiledField(structType, structType.Field(0))
+		assert.NoError(t, err)
+		assert.Same(t, plan.fields[0].node, node)
+	}
+	assert.EqualError(t, validator.Validate(context.Background(), Aliased{Name: "ab"}),
+		"validation of alias name of Field Name failed: len field Name has length 2, but should have length 3")
+
+	// tags that cannot be compiled are compiled again for every validation
+	assert.False(t, plan.fields[1].compiled)
+	assert.IsType(t, &TagSyntaxError{}, validator.Validate(context.Background(), Aliased{Name: "abc"}))
+
+	// the compiled tags are reset when aliases or custom validators are registered
+	assert.NoError(t, validator.RegisterAlias("name", "len(2)"))
+	plan = validator.plan(structType)
+	assert.Equal(t, "len(2)", plan.fields[0].node.Children[0].SubTag)
+
+	plan = validator.plan(structType)
+	validator.RegisterCustomValidator(dv.Len())
+	assert.NotSame(t, plan, validator.plan(structType))
+}
+
+func TestValidator_Validate_ignoredAndOpaqueFields(t *testing.T) {
+	for name, validator := range map[string]*Validator{"cached": NewValidator(), "uncached": {CustomValidators: NewValidator().CustomValidators}} {
+		t.Run(name, func(t *testing.T) {
+			validator.RegisterOpaqueType(&PlannedOpaque{})
+
+			parent := &PlannedParent{Child: &PlannedChild{Name: "abc"}}
+			user := PlannedUser{Name: "abc", Address: &PlannedAddress{Street: "Main"}, Ignored: PlannedAddress{}, Any: PlannedOpaque{}, Parent: parent}
+			assert.NoError(t, validator.Validate(context.Background(), user))
+			assert.NoError(t, validator.ValidateAll(context.Background(), user))
+
+			user.Address.Street = ""
+			assert.EqualError(t, validator.Validate(context.Background(), user), "non-zero field Street has zero value")
+
+			user.Address.Street = "Main"
+			parent.Child.Name = "ab"
+
+			assert.EqualError(t, validator.Validate(context.Background(), user), "len field Name has length 2, but should have length 3")
+
+			assert.NoError(t, validator.Validate(context.Background(), struct {
+				Name string `validator:"-"`
+			}{}))
+		})
+	}
+}
+
+func TestValidator_ValidateStruct_rulesOfSkippedFields(t *testing.T) {
+	validator := NewValidator()
+	validator.RegisterOpaqueType(PlannedOpaque{})
+
+	parent := &PlannedParent{Child: &PlannedChild{Name: "abc"}}
+	user := PlannedUser{Name: "abc", Address: &PlannedAddress{Street: "Main"}, Untagged: PlannedUntagged{Value: "ab"}, Parent: parent}
+	assert.True(t, validator.plan(reflect.TypeOf(user)).fields[2].skip)
+
+	// rules defined in code are validated although the plan skips the untagged field
+	err := validator.ValidateStruct(context.Background(), &user,
+		Field(&user.Untagged.Value, NewValidationNode("len(3)")),
+	)
+	assert.EqualError(t, err, "len field Value has length 2, but should have length 3")
+
+	err = validator.ValidateStruct(context.Background(), &user, Field(&user.Note, NewValidationNode("len(0)")))
+	assert.NoError(t, err)
+
+	user.Note = "note"
+	err = validator.ValidateStruct(context.Background(), &user, Field(&user.Note, NewValidationNode("len(0)")))
+	assert.EqualError(t, err, "len field Note has length 4, but should have length 0")
+}
+
+func TestValidator_Validate_hooksOfSkippedFields(t *testing.T) {
+	user := PlannedUser{Name: "abc", Address: &PlannedAddress{Street: "Main"}, Parent: &PlannedParent{Child: &PlannedChild{Name: "abc"}}}
+
+	observedPaths := func(validator *Validator) []string {
+		hooks := &recordingHooks{}
+		validator.Hooks = hooks
+		validator.RegisterOpaqueType(PlannedOpaque{})
+		assert.NoError(t, validator.Validate(context.Background(), user))
+
+		var fields []string
+		for _, event := range hooks.events {
+			if strings.HasPrefix(event, "field ") {
+				fields = append(fields, event)
+			}
+		}
+		return fields
+	}
+
+	// the plans only skip fields which are not observed anyway
+	cached := observedPaths(NewValidator())
+	assert.Equal(t, []string{
+		"field validator.PlannedUser.Name false",
+		"field validator.PlannedUser.Address.Street false",
+		"field validator.PlannedUser.Opaque false",
+		"field validator.PlannedUser.Parent.Child.Name false",
+	}, cached)
+	assert.Equal(t, cached, observedPaths(&Validator{CustomValidators: NewValidator().CustomValidators}))
+}
@@ -0,0 +1,116 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// parsePlaygroundTag translates a tag of the comma separated syntax used by github.com/go-playground/validator
+// into an expression tree of the native syntax.
+//
+// Supported are:
+//   - comma separated rules which all have to succeed e.g. required,email
+//   - alternatives separated by | e.g. email|len=0
+//   - parameters of the form rule=param which are translated into rule(param) e.g. min=3 => min(3)
+//   - space separated values of oneof which are translated into string literals e.g. oneof=red green => oneof('red','green')
+//   - omitempty which skips all rules of the tag if the field has a zero value, including rules preceding it
+//     e.g. min=3,omitempty => if(non-zero)then(min(3)). After a dive it only skips the rules of zero elements.
+//   - dive which validates all following rules on every element of a slice, array or map
+//
+// Returns nil if the tag does not contain any validation or is "-".
+func parsePlaygroundTag(tag string) (*Node, error) {
+	if tag == "" || tag == "-" {
+		return nil, nil
+	}
+
+	return parsePlaygroundRules(tag, strings.Split(tag, ","))
+}
+
+func parsePlaygroundRules(tag string, rules []string) (*Node, error) {
+	var nodes []*Node
+	omitEmpty := false
+
+	for i, rule := range rules {
+		rule = strings.TrimSpace(rule)
+
+		if rule == "omitempty" {
+			omitEmpty = true
+			continue
+		}
+
+		if rule == "dive" {
+			elem, err := parsePlaygroundRules(tag, rules[i+1:])
+			if err != nil {
+				return nil, err
+			}
+
+			if elem != nil {
+				nodes = append(nodes, NewDiveNode(elem))
+			}
+			break
+		}
+
+		node, err := parsePlaygroundAlternatives(tag, rule)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+
+	// logical operators are right associative
+	node := nodes[len(nodes)-1]
+	for i := len(nodes) - 2; i >= 0; i-- {
+		node = NewAndNode(nodes[i], node)
+	}
+
+	if omitEmpty {
+		node = NewIfNode(NewValidationNode("non-zero"), node, nil)
+	}
+
+	return node, nil
+}
+
+func parsePlaygroundAlternatives(tag, rule string) (*Node, error) {
+	alternatives := strings.Split(rule, "|")
+
+	var node *Node
+	for j := len(alternatives) - 1; j >= 0; j-- {
+		alternative := strings.TrimSpace(alternatives[j])
+		if alternative == "" {
+			return nil, SyntaxErrorf("empty rule").WithField("tag", tag)
+		}
+
+		validation := NewValidationNode(alternative)
+		if i := strings.Index(alternative, "="); i >= 0 {
+			name, param := alternative[:i], alternative[i+1:]
+			if name == "oneof" {
+				param = quotePlaygroundValues(param)
+			}
+			validation = NewValidationNode(fmt.Sprintf("%v(%v)", name, param))
+		}
+
+		if node == nil {
+			node = validation
+		} else {
+			node = NewOrNode(validation, node)
+		}
+	}
+
+	return node, nil
+}
+
+// quotePlaygroundValues translates space separated values into comma separated string literals
+func quotePlaygroundValues(param string) string {
+	values := strings.Fields(param)
+	for i, value := range values {
+		values[i] = cv.Quote(value)
+	}
+
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePlaygroundTag(t *testing.T) {
+	tests := map[string]string{
+		"required":                       "required",
+		"required,email":                 "required&&email",
+		"required,len=9,email":           "required&&(len(9)&&email)",
+		"email|len=0":                    "email||len(0)",
+		"required,email|len=0":           "required&&(email||len(0))",
+		"oneof=red green":                "oneof('red','green')",
+		"omitempty,min=3,max=5":          "if(non-zero)then(min(3)&&max(5))",
+		"min=3,omitempty":                "if(non-zero)then(min(3))",
+		"required,dive,required,max=3":   "required&&dive(required&&max(3))",
+		"omitempty,dive,omitempty,email": "if(non-zero)then(dive(if(non-zero)then(email)))",
+	}
+
+	for tag, expected := range tests {
+		t.Run(tag, func(t *testing.T) {
+			node, err := parsePlaygroundTag(tag)
+
+			assert.NoError(t, err)
+			assert.Equal(t, expected, render(node))
+		})
+	}
+}
+
+func TestValidator_Validate_playgroundOmitEmptyAfterRules(t *testing.T) {
+	type omitEmptyStruct struct {
+		Name string `validate:"min=3,omitempty"`
+	}
+
+	v := NewValidator()
+	v.SetTagKeys(PlaygroundTagKey("validate"))
+
+	assert.NoError(t, v.Validate(context.Background(), omitEmptyStruct{}))
+	assert.NoError(t, v.Validate(context.Background(), omitEmptyStruct{Name: "abc"}))
+	assert.Error(t, v.Validate(context.Background(), omitEmptyStruct{Name: "ab"}))
+}
+
+func TestParsePlaygroundTag_subTagsAreNativeSyntax(t *testing.T) {
+	node, err := parsePlaygroundTag("required,email|len=0,dive,min=1")
+	assert.NoError(t, err)
+
+	reparsed, err := parseTag(node.SubTag)
+	assert.NoError(t, err)
+
+	assert.Equal(t, render(node), render(reparsed))
+}
+
+func TestParsePlaygroundTag_withoutValidation(t *testing.T) {
+	for _, tag := range []string{"", "-", "omitempty", "dive"} {
+		t.Run(tag, func(t *testing.T) {
+			node, err := parsePlaygroundTag(tag)
+
+			assert.NoError(t, err)
+			assert.Nil(t, node)
+		})
+	}
+}
+
+func TestParsePlaygroundTag_failsForEmptyRule(t *testing.T) {
+	for _, tag := range []string{"required,,email", "email|"} {
+		t.Run(tag, func(t *testing.T) {
+			_, err := parsePlaygroundTag(tag)
+
+			assert.IsType(t, &TagSyntaxError{}, err)
+		})
+	}
+}
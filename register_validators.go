@@ -9,4 +9,19 @@ func (v *Validator) RegisterDefaultCustomValidators() {
 	v.RegisterCustomValidator(dv.Required())
 	v.RegisterCustomValidator(dv.Email())
 	v.RegisterCustomValidator(dv.Len())
+	v.RegisterCustomValidator(dv.Min())
+	v.RegisterCustomValidator(dv.Max())
+	v.RegisterCustomValidator(dv.OneOf())
+	v.RegisterCustomValidator(dv.Regex())
+}
+
+// RegisterDefaultModifiers registers the default modifiers of the normalization on the validator instance.
+func (v *Validator) RegisterDefaultModifiers() {
+	v.RegisterModifier(dv.Trim())
+	v.RegisterModifier(dv.Lower())
+	v.RegisterModifier(dv.Upper())
+	v.RegisterModifier(dv.Title())
+	v.RegisterModifier(dv.CollapseSpaces())
+	v.RegisterModifier(dv.NFC())
+	v.RegisterModifier(dv.StripControl())
 }
@@ -0,0 +1,445 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// RemoteBackend looks up the values of fields for a remote validator e.g. in a database or another service
+type RemoteBackend interface {
+	// Lookup returns whether the values of the keys are valid for the sub tag of the validation context e.g. exists(users).
+	// Keys that are missing in the result are invalid. The context is canceled if the timeout of the remote validator expires.
+	Lookup(ctx context.Context, validationCtx *cv.ValidationContext, keys []string) (map[string]bool, error)
+}
+
+// RemoteBackendFunc is an adapter to use a function as RemoteBackend
+type RemoteBackendFunc func(ctx context.Context, validationCtx *cv.ValidationContext, keys []string) (map[string]bool, error)
+
+// Lookup calls the function
+// Implements RemoteBackend interface
+func (f RemoteBackendFunc) Lookup(ctx context.Context, validationCtx *cv.ValidationContext, keys []string) (map[string]bool, error) {
+	return f(ctx, validationCtx, keys)
+}
+
+// RemoteError is returned by remote validators if the value of a field is invalid or its lookup failed
+type RemoteError struct {
+	// Key is the value of the field that has been looked up
+	Key string
+	// Err is the error of the lookup or nil if the value is invalid
+	Err error
+
+	message string
+}
+
+// Error returns the error message string
+// Implements error interface
+func (err *RemoteError) Error() string {
+	return err.message
+}
+
+// Unwrap returns the error of the lookup
+func (err *RemoteError) Unwrap() error {
+	return err.Err
+}
+
+// RemoteValidator validates the values of fields by lookups in a RemoteBackend.
+// The lookups of all fields are batched per validation and run concurrently.
+type RemoteValidator struct {
+	ID       string
+	TagRegex *regexp.Regexp
+	Backend  RemoteBackend
+	// Params are the typed parameters of the sub tag e.g. the table of exists(users)
+	Params []cv.Param
+	// Timeout is the maximum duration of a lookup. If zero, only the deadline of the context of the validation applies.
+	Timeout time.Duration
+	// CacheTTL is the duration the results of lookups are cached by the RemoteCache of the validator. If zero, the results are not cached.
+	CacheTTL time.Duration
+	// MaxBatchSize is the maximum number of keys per lookup. If zero, all keys of a sub tag are looked up at once.
+	MaxBatchSize int
+}
+
+// NewRemoteValidator creates a new remote validator for the sub tags matching the regex
+func NewRemoteValidator(id string, tagRegex *regexp.Regexp, backend RemoteBackend, params ...cv.Param) *RemoteValidator {
+	return &RemoteValidator{
+		ID:       id,
+		TagRegex: tagRegex,
+		Backend:  backend,
+		Params:   params,
+	}
+}
+
+// WithTimeout sets the maximum duration of a lookup
+func (rv *RemoteValidator) WithTimeout(timeout time.Duration) *RemoteValidator {
+	rv.Timeout = timeout
+	return rv
+}
+
+// WithCacheTTL sets the duration the results of lookups are cached
+func (rv *RemoteValidator) WithCacheTTL(ttl time.Duration) *RemoteValidator {
+	rv.CacheTTL = ttl
+	return rv
+}
+
+// WithMaxBatchSize sets the maximum number of keys per lookup
+func (rv *RemoteValidator) WithMaxBatchSize(size int) *RemoteValidator {
+	rv.MaxBatchSize = size
+	return rv
+}
+
+// RegisterRemoteValidator registers a remote validator for the validator.
+// It is registered as custom validator as well, so that its sub tags can be used like the sub tags of all other custom validators.
+// Nil values are not looked up and are valid, all other values are looked up by their default format e.g. 42 for an int.
+func (v *Validator) RegisterRemoteValidator(rv *RemoteValidator) {
+	if v.remoteValidators == nil {
+		v.remoteValidators = map[string]*RemoteValidator{}
+	}
+	v.remoteValidators[rv.ID] = rv
+
+	v.RegisterCustomValidator(cv.NewCustomValidator(rv.ID, rv.TagRegex, v.remoteValidation(rv), cv.NewCustomValidatorConfig(), rv.Params...))
+}
+
+type remoteBatchKey struct{}
+
+// remoteBatch contains the lookups of the remote validators of a validation.
+// The tags are evaluated twice: the first run only calls the remote validators to collect the keys of all lookups,
+// which are resolved before the second run validates the fields.
+type remoteBatch struct {
+	mu         sync.Mutex
+	collecting bool
+	cache      RemoteCache
+	// pending contains the lookups that have not been resolved yet by their remote validators and sub tags
+	pending map[string]*remoteLookup
+	// results contains the results of the lookups by their cache keys
+	results map[string]remoteResult
+}
+
+type remoteLookup struct {
+	validator     *RemoteValidator
+	validationCtx *cv.ValidationContext
+	keys          []string
+	added         map[string]bool
+}
+
+type remoteResult struct {
+	valid bool
+	err   error
+}
+
+func newRemoteBatch(cache RemoteCache) *remoteBatch {
+	return &remoteBatch{
+		cache:   cache,
+		pending: map[string]*remoteLookup{},
+		results: map[string]remoteResult{},
+	}
+}
+
+// prefetchRemote resolves the lookups of all remote validators of a validation that are collected by the provided function
+// and returns a context containing their results. Returns the context unchanged if no remote validators are registered
+// or the context contains the lookups of a validation already.
+func (v *Validator) prefetchRemote(ctx context.Context, collect func(collector *Validator, ctx context.Context)) context.Context {
+	if len(v.remoteValidators) == 0 || ctx.Value(remoteBatchKey{}) != nil {
+		return ctx
+	}
+
+	batch := newRemoteBatch(v.RemoteCache)
+	batch.collecting = true
+	ctx = context.WithValue(ctx, remoteBatchKey{}, batch)
+
+	// the tags of all fields are evaluated to collect the keys of all lookups without calling the other custom validators
+	collector := *v
+	collector.collected = &ValidationErrors{}
+	collector.collectingRemote = true
+	// the collecting run is neither observed nor traced since its results are discarded
+	collector.Hooks = nil
+	collector.Tracer = nil
+	collector.tracing = false
+	collect(&collector, ctx)
+
+	batch.collecting = false
+	batch.resolve(ctx)
+
+	return ctx
+}
+
+// remoteValidation creates the validation function of a remote validator, which uses the results of the lookups of the validation.
+// Keys that have not been collected before are looked up immediately.
+func (v *Validator) remoteValidation(rv *RemoteValidator) cv.CustomValidationFunc {
+	return func(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
+		value, ok := cv.Deref(f.Value)
+		if !ok || !value.CanInterface() {
+			return nil
+		}
+		key := fmt.Sprint(value.Interface())
+
+		batch, ok := ctx.Value(remoteBatchKey{}).(*remoteBatch)
+		if !ok {
+			batch = newRemoteBatch(v.RemoteCache)
+		}
+
+		if batch.collecting {
+			batch.add(rv, vCtx, key)
+			return nil
+		}
+
+		result, ok := batch.result(rv, vCtx, key)
+		if !ok {
+			batch.add(rv, vCtx, key)
+			batch.resolve(ctx)
+			result, _ = batch.result(rv, vCtx, key)
+		}
+
+		fieldName := vCtx.ForField(f).FieldName
+		if result.err != nil {
+			return &RemoteError{Key: key, Err: result.err, message: fmt.Sprintf("%v field %v: lookup of value %v failed: %v", rv.ID, fieldName, key, result.err)}
+		}
+
+		if !result.valid {
+			return &RemoteError{Key: key, message: fmt.Sprintf("%v field %v has invalid value %v", rv.ID, fieldName, key)}
+		}
+
+		return nil
+	}
+}
+
+// add adds a key to the pending lookups of a remote validator and sub tag
+func (batch *remoteBatch) add(rv *RemoteValidator, vCtx *cv.ValidationContext, key string) {
+	batch.mu.Lock()
+	defer batch.mu.Unlock()
+
+	if _, ok := batch.results[remoteCacheKey(rv, vCtx, key)]; ok {
+		return
+	}
+
+	lookupKey := rv.ID + "\x00" + vCtx.SubTag
+	lookup, ok := batch.pending[lookupKey]
+	if !ok {
+		lookupCtx := *vCtx
+		lookupCtx.FieldName = ""
+		lookup = &remoteLookup{validator: rv, validationCtx: &lookupCtx, added: map[string]bool{}}
+		batch.pending[lookupKey] = lookup
+	}
+
+	if !lookup.added[key] {
+		lookup.added[key] = true
+		lookup.keys = append(lookup.keys, key)
+	}
+}
+
+func (batch *remoteBatch) result(rv *RemoteValidator, vCtx *cv.ValidationContext, key string) (remoteResult, bool) {
+	batch.mu.Lock()
+	defer batch.mu.Unlock()
+
+	result, ok := batch.results[remoteCacheKey(rv, vCtx, key)]
+	return result, ok
+}
+
+// resolve looks up the keys of all pending lookups that are not cached concurrently
+func (batch *remoteBatch) resolve(ctx context.Context) {
+	batch.mu.Lock()
+	pending := batch.pending
+	batch.pending = map[string]*remoteLookup{}
+	batch.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, lookup := range pending {
+		keys := batch.uncached(lookup)
+
+		size := lookup.validator.MaxBatchSize
+		if size <= 0 {
+			size = len(keys)
+		}
+
+		for start := 0; start < len(keys); start += size {
+			end := start + size
+			if end > len(keys) {
+				end = len(keys)
+			}
+
+			wg.Add(1)
+			go func(lookup *remoteLookup, keys []string) {
+				defer wg.Done()
+				batch.lookup(ctx, lookup, keys)
+			}(lookup, keys[start:end])
+		}
+	}
+
+	wg.Wait()
+}
+
+// uncached stores the cached results of the keys of a lookup and returns the keys that are not cached
+func (batch *remoteBatch) uncached(lookup *remoteLookup) []string {
+	rv := lookup.validator
+	if batch.cache == nil || rv.CacheTTL <= 0 {
+		return lookup.keys
+	}
+
+	var keys []string
+	for _, key := range lookup.keys {
+		cacheKey := remoteCacheKey(rv, lookup.validationCtx, key)
+		valid, ok := batch.cache.Get(cacheKey)
+		if !ok {
+			keys = append(keys, key)
+			continue
+		}
+
+		batch.mu.Lock()
+		batch.results[cacheKey] = remoteResult{valid: valid}
+		batch.mu.Unlock()
+	}
+
+	return keys
+}
+
+// lookup looks up the keys in the backend of the remote validator and stores the results
+func (batch *remoteBatch) lookup(ctx context.Context, lookup *remoteLookup, keys []string) {
+	rv := lookup.validator
+	if rv.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, rv.Timeout)
+		defer cancel()
+	}
+
+	valid, err := lookupKeys(ctx, lookup, keys)
+
+	batch.mu.Lock()
+	defer batch.mu.Unlock()
+
+	for _, key := range keys {
+		cacheKey := remoteCacheKey(rv, lookup.validationCtx, key)
+		if err != nil {
+			batch.results[cacheKey] = remoteResult{err: err}
+			continue
+		}
+
+		batch.results[cacheKey] = remoteResult{valid: valid[key]}
+		if batch.cache != nil && rv.CacheTTL > 0 {
+			batch.cache.Set(cacheKey, valid[key], rv.CacheTTL)
+		}
+	}
+}
+
+// lookupKeys looks up the keys in the backend of the remote validator and converts panics of the backend into a PanicError,
+// since the lookups run in their own goroutines
+func lookupKeys(ctx context.Context, lookup *remoteLookup, keys []string) (valid map[string]bool, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = newPanicError(recovered, nil, lookup.validator.ID)
+		}
+	}()
+
+	return lookup.validator.Backend.Lookup(ctx, lookup.validationCtx, keys)
+}
+
+// remoteCacheKey identifies the result of the lookup of a key for a remote validator and sub tag
+func remoteCacheKey(rv *RemoteValidator, vCtx *cv.ValidationContext, key string) string {
+	return rv.ID + "\x00" + vCtx.SubTag + "\x00" + key
+}
+
+// RemoteCache caches the results of the lookups of remote validators
+type RemoteCache interface {
+	// Get returns the cached result of the key and whether the key is cached and has not expired
+	Get(key string) (valid bool, ok bool)
+	// Set caches the result of the key for the duration of the ttl
+	Set(key string, valid bool, ttl time.Duration)
+}
+
+// memoryCacheSweepSize is the minimum number of entries of a MemoryCache before expired entries are swept
+const memoryCacheSweepSize = 1024
+
+// MemoryCache is a RemoteCache that keeps the results in memory.
+// Expired entries are removed when they are read and swept by Set whenever the number of entries has doubled since the last sweep,
+// so that the cache does not grow with keys that are never read again e.g. user input of uniqueness checks.
+// If MaxEntries is set, results are not cached while the cache is full after sweeping.
+type MemoryCache struct {
+	// MaxEntries is the maximum number of cached results, the number is not bounded if it is 0
+	MaxEntries int
+
+	mu        sync.Mutex
+	entries   map[string]memoryCacheEntry
+	now       func() time.Time
+	nextSweep int
+}
+
+type memoryCacheEntry struct {
+	valid   bool
+	expires time.Time
+}
+
+// NewMemoryCache creates a new empty in-memory cache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		entries:   map[string]memoryCacheEntry{},
+		now:       time.Now,
+		nextSweep: memoryCacheSweepSize,
+	}
+}
+
+// Get returns the cached result of the key and whether the key is cached and has not expired
+// Implements RemoteCache interface
+func (c *MemoryCache) Get(key string) (bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return false, false
+	}
+
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return false, false
+	}
+
+	return entry.valid, true
+}
+
+// Set caches the result of the key for the duration of the ttl
+// Implements RemoteCache interface
+func (c *MemoryCache) Set(key string, valid bool, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	_, cached := c.entries[key]
+	if !cached && (len(c.entries) >= c.nextSweep || c.full()) {
+		c.sweep(now)
+	}
+
+	if !cached && c.full() {
+		return
+	}
+
+	c.entries[key] = memoryCacheEntry{valid: valid, expires: now.Add(ttl)}
+}
+
+// Len returns the number of cached results including expired results that have not been removed yet
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+// sweep removes all expired entries
+func (c *MemoryCache) sweep(now time.Time) {
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+
+	c.nextSweep = 2 * len(c.entries)
+	if c.nextSweep < memoryCacheSweepSize {
+		c.nextSweep = memoryCacheSweepSize
+	}
+}
+
+// full returns whether the cache contains MaxEntries entries
+func (c *MemoryCache) full() bool {
+	return c.MaxEntries > 0 && len(c.entries) >= c.MaxEntries
+}
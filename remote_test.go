@@ -0,0 +1,210 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+	"github.com/gogo-gadget/validator/remotetest"
+)
+
+type RemoteTeam struct {
+	Owner   string   `validator:"exists(users)"`
+	Members []string `validator:"dive(exists(users))"`
+	Parent  *string  `validator:"exists(teams)"`
+	Name    string   `validator:"len(3) && exists(names)"`
+}
+
+func newRemoteValidator(backend *remotetest.Backend) (*Validator, *RemoteValidator) {
+	validator := NewValidator()
+	rv := NewRemoteValidator("exists", regexp.MustCompile(`^exists\(.*\)$`), backend, cv.StringParam("table"))
+	validator.RegisterRemoteValidator(rv)
+
+	return validator, rv
+}
+
+func TestValidator_Validate_batchesRemoteLookups(t *testing.T) {
+	backend := remotetest.NewBackend("alice", "bob", "carol", "admins", "abc")
+	validator, _ := newRemoteValidator(backend)
+
+	parent := "admins"
+	err := validator.Validate(context.Background(), RemoteTeam{Owner: "alice", Members: []string{"bob", "carol", "alice"}, Parent: &parent, Name: "abc"})
+	assert.NoError(t, err)
+
+	assert.ElementsMatch(t, [][]string{{"alice", "bob", "carol"}, {"admins"}, {"abc"}}, backend.Calls())
+
+	// nil values are not looked up
+	err = validator.Validate(context.Background(), &RemoteTeam{Owner: "alice", Members: []string{"bob", "dave"}, Name: "abc"})
+	assert.EqualError(t, err, "exists field Members[1] has invalid value dave")
+	assert.Len(t, backend.Calls(), 5)
+
+	var remoteErr *RemoteError
+	if assert.True(t, errors.As(err, &remoteErr)) {
+		assert.Equal(t, "dave", remoteErr.Key)
+		assert.Nil(t, remoteErr.Err)
+	}
+
+	err = validator.ValidateAll(context.Background(), RemoteTeam{Owner: "eve", Members: []string{"dave"}, Name: "xyz"})
+	var errs ValidationErrors
+	if assert.True(t, errors.As(err, &errs)) && assert.Len(t, errs, 3) {
+		assert.Equal(t, "Owner", errs[0].Path)
+		assert.Equal(t, "Members[0]", errs[1].Path)
+		assert.Equal(t, "Name", errs[2].Path)
+	}
+	assert.Len(t, backend.Calls(), 7)
+}
+
+type RemoteOwner struct {
+	Owner string `validator:"exists(users)"`
+}
+
+func TestValidator_Validate_cachesRemoteLookups(t *testing.T) {
+	backend := remotetest.NewBackend("alice")
+	validator, rv := newRemoteValidator(backend)
+	rv.WithCacheTTL(time.Minute)
+
+	cache := NewMemoryCache()
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	validator.RemoteCache = cache
+
+	assert.NoError(t, validator.Validate(context.Background(), RemoteOwner{Owner: "alice"}))
+	assert.NoError(t, validator.Validate(context.Background(), RemoteOwner{Owner: "alice"}))
+	assert.Len(t, backend.Calls(), 1)
+
+	backend.SetValid("alice", false)
+	assert.NoError(t, validator.Validate(context.Background(), RemoteOwner{Owner: "alice"}))
+
+	now = now.Add(time.Minute)
+	assert.EqualError(t, validator.Validate(context.Background(), RemoteOwner{Owner: "alice"}), "exists field Owner has invalid value alice")
+	assert.Len(t, backend.Calls(), 2)
+}
+
+func TestValidator_Validate_remoteLookupErrors(t *testing.T) {
+	backend := remotetest.NewBackend("alice")
+	validator, rv := newRemoteValidator(backend)
+	rv.WithTimeout(10 * time.Millisecond)
+
+	backend.SetDelay(time.Second)
+	err := validator.Validate(context.Background(), RemoteOwner{Owner: "alice"})
+	assert.EqualError(t, err, "exists field Owner: lookup of value alice failed: context deadline exceeded")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	backend.SetDelay(0)
+	backend.SetError(errors.New("connection refused"))
+	err = validator.Validate(context.Background(), RemoteOwner{Owner: "alice"})
+	assert.EqualError(t, err, "exists field Owner: lookup of value alice failed: connection refused")
+}
+
+func TestValidator_Validate_remoteLookupsRunConcurrently(t *testing.T) {
+	backend := remotetest.NewBackend("a", "b", "c")
+	validator, rv := newRemoteValidator(backend)
+	rv.WithMaxBatchSize(1)
+	backend.SetDelay(50 * time.Millisecond)
+
+	start := time.Now()
+	err := validator.Validate(context.Background(), struct {
+		Members []string `validator:"dive(exists(users))"`
+	}{Members: []string{"a", "b", "c"}})
+	assert.NoError(t, err)
+	assert.Len(t, backend.Calls(), 3)
+	assert.Less(t, time.Since(start), 150*time.Millisecond)
+}
+
+func TestValidator_ValidateStruct_remoteLookups(t *testing.T) {
+	backend := remotetest.NewBackend("alice")
+	validator, _ := newRemoteValidator(backend)
+
+	owner := RemoteOwner{Owner: "alice"}
+	err := validator.ValidateStruct(context.Background(), &owner, Field(&owner.Owner, NewValidationNode("exists(users)")))
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"alice"}}, backend.Calls())
+}
+
+func TestValidator_Validate_collectsRemoteLookupsWithoutOtherValidators(t *testing.T) {
+	backend := remotetest.NewBackend("alice", "bob", "admins")
+	validator, _ := newRemoteValidator(backend)
+
+	calls := 0
+	validator.RegisterCustomValidator(cv.NewCustomValidator("count", regexp.MustCompile(`^count$`),
+		func(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
+			calls++
+			return nil
+		}, cv.NewCustomValidatorConfig()))
+
+	type countedTeam struct {
+		Owner  string `validator:"count && exists(users)"`
+		Member string `validator:"if(count)then(exists(users))else(exists(teams))"`
+		Parent string `validator:"if(!count)then(exists(users))else(exists(teams))"`
+	}
+
+	err := validator.Validate(context.Background(), countedTeam{Owner: "alice", Member: "bob", Parent: "admins"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	// the keys of all branches are collected, so that no lookups are left for the validation
+	assert.ElementsMatch(t, [][]string{{"alice", "bob", "admins"}, {"bob", "admins"}}, backend.Calls())
+}
+
+func TestMemoryCache(t *testing.T) {
+	cache := NewMemoryCache()
+
+	_, ok := cache.Get("key")
+	assert.False(t, ok)
+
+	cache.Set("key", true, time.Minute)
+	valid, ok := cache.Get("key")
+	assert.True(t, ok)
+	assert.True(t, valid)
+
+	cache.Set("key", false, -time.Minute)
+	_, ok = cache.Get("key")
+	assert.False(t, ok)
+}
+
+func TestMemoryCache_sweepsExpiredEntries(t *testing.T) {
+	now := time.Now()
+	cache := NewMemoryCache()
+	cache.now = func() time.Time { return now }
+
+	for i := 0; i < memoryCacheSweepSize; i++ {
+		cache.Set(fmt.Sprintf("expired-%v", i), true, time.Minute)
+	}
+	assert.Equal(t, memoryCacheSweepSize, cache.Len())
+
+	// expired entries that are never read again are swept by Set
+	now = now.Add(time.Hour)
+	cache.Set("key", true, time.Minute)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestMemoryCache_maxEntries(t *testing.T) {
+	now := time.Now()
+	cache := NewMemoryCache()
+	cache.now = func() time.Time { return now }
+	cache.MaxEntries = 2
+
+	cache.Set("a", true, time.Minute)
+	cache.Set("b", true, time.Hour)
+	cache.Set("c", true, time.Hour)
+	_, ok := cache.Get("c")
+	assert.False(t, ok)
+
+	// cached keys are updated and expired entries make room for new keys
+	cache.Set("b", false, time.Hour)
+	valid, ok := cache.Get("b")
+	assert.True(t, ok)
+	assert.False(t, valid)
+
+	now = now.Add(30 * time.Minute)
+	cache.Set("c", true, time.Hour)
+	_, ok = cache.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 2, cache.Len())
+}
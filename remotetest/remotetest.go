@@ -0,0 +1,102 @@
+// Package remotetest provides an in-memory backend of remote validators for tests.
+// Usage:
+//
+//	backend := remotetest.NewBackend("alice", "bob")
+//	v.RegisterRemoteValidator(validator.NewRemoteValidator("exists", regexp.MustCompile("^exists$"), backend))
+package remotetest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// Backend is an in-memory remote backend that records its lookups.
+// Implements validator.RemoteBackend interface
+type Backend struct {
+	mu    sync.Mutex
+	valid map[string]bool
+	err   error
+	delay time.Duration
+	calls [][]string
+}
+
+// NewBackend creates a new in-memory backend for which the provided keys are valid
+func NewBackend(validKeys ...string) *Backend {
+	b := &Backend{valid: map[string]bool{}}
+	for _, key := range validKeys {
+		b.valid[key] = true
+	}
+
+	return b
+}
+
+// SetValid sets whether a key is valid
+func (b *Backend) SetValid(key string, valid bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.valid[key] = valid
+}
+
+// SetError sets the error that is returned by all following lookups or resets it if nil
+func (b *Backend) SetError(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.err = err
+}
+
+// SetDelay sets the duration every following lookup takes unless its context is done before
+func (b *Backend) SetDelay(delay time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.delay = delay
+}
+
+// Lookup returns whether the keys are valid after the delay of the backend or the error of the context if it is done before
+func (b *Backend) Lookup(ctx context.Context, validationCtx *cv.ValidationContext, keys []string) (map[string]bool, error) {
+	b.mu.Lock()
+	b.calls = append(b.calls, append([]string(nil), keys...))
+	delay, err := b.delay, b.err
+	b.mu.Unlock()
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	result := map[string]bool{}
+	for _, key := range keys {
+		result[key] = b.valid[key]
+	}
+
+	return result, nil
+}
+
+// Calls returns the keys of all lookups in the order they have been received
+func (b *Backend) Calls() [][]string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	calls := make([][]string, len(b.calls))
+	copy(calls, b.calls)
+
+	return calls
+}
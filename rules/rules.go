@@ -0,0 +1,195 @@
+// Package rules provides a type-checked builder for validation rules as an alternative to struct field tags.
+// The rules create the same expression tree as the tag parser and are validated by the registered custom validators.
+// Usage:
+//
+//	err := v.ValidateStruct(ctx, &s,
+//		validator.Field(&s.Name, rules.Required(), rules.Len(MaxNameLength)),
+//		validator.Field(&s.Email, rules.Or(rules.Email(), rules.Len(0))),
+//	)
+package rules
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogo-gadget/validator"
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// Arg is the type of the arguments of rules. Strings are written as string literals,
+// durations by their string representation and all other arguments by their default format.
+type Arg interface {
+	~string | ~bool | ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64
+}
+
+// NewRule returns a constructor of rules for the custom validator or alias with the provided name which has no parameters.
+// Usage:
+//
+//	city := rules.NewRule("city")
+//	validator.Field(&s.City, city())
+func NewRule(name string) func() *validator.Node {
+	return func() *validator.Node {
+		return validation(name)
+	}
+}
+
+// NewRule1 returns a constructor of rules for the custom validator with the provided name which has a single parameter of type A.
+// Usage:
+//
+//	maxAge := rules.NewRule1[time.Duration]("max-age")
+//	validator.Field(&s.Timeout, maxAge(time.Hour)) // max-age(1h0m0s)
+func NewRule1[A Arg](name string) func(a A) *validator.Node {
+	return func(a A) *validator.Node {
+		return validation(name, a)
+	}
+}
+
+// NewRule2 returns a constructor of rules for the custom validator with the provided name which has two parameters of type A and B
+func NewRule2[A, B Arg](name string) func(a A, b B) *validator.Node {
+	return func(a A, b B) *validator.Node {
+		return validation(name, a, b)
+	}
+}
+
+// NewVariadicRule returns a constructor of rules for the custom validator with the provided name which has at least one parameter of type A
+func NewVariadicRule[A Arg](name string) func(value A, values ...A) *validator.Node {
+	return func(value A, values ...A) *validator.Node {
+		args := []interface{}{value}
+		for _, v := range values {
+			args = append(args, v)
+		}
+
+		return validation(name, args...)
+	}
+}
+
+// validation creates a rule for the custom validator or alias matching the provided name and arguments
+func validation(name string, args ...interface{}) *validator.Node {
+	if len(args) == 0 {
+		return validator.NewValidationNode(name)
+	}
+
+	formattedArgs := make([]string, len(args))
+	for i, arg := range args {
+		formattedArgs[i] = formatArg(arg)
+	}
+
+	return validator.NewValidationNode(fmt.Sprintf("%v(%v)", name, strings.Join(formattedArgs, ",")))
+}
+
+// NonNil creates a rule for the non-nil custom validator
+func NonNil() *validator.Node {
+	return validation("non-nil")
+}
+
+// NonZero creates a rule for the non-zero custom validator
+func NonZero() *validator.Node {
+	return validation("non-zero")
+}
+
+// Required creates a rule for the required custom validator
+func Required() *validator.Node {
+	return validation("required")
+}
+
+// Email creates a rule for the email custom validator
+func Email() *validator.Node {
+	return validation("email")
+}
+
+// Len creates a rule for the len custom validator
+func Len(length int) *validator.Node {
+	return validation("len", length)
+}
+
+// Min creates a rule for the min custom validator
+func Min(min float64) *validator.Node {
+	return validation("min", min)
+}
+
+// Max creates a rule for the max custom validator
+func Max(max float64) *validator.Node {
+	return validation("max", max)
+}
+
+// OneOf creates a rule for the oneof custom validator
+func OneOf(value string, values ...string) *validator.Node {
+	args := []interface{}{value}
+	for _, v := range values {
+		args = append(args, v)
+	}
+
+	return validation("oneof", args...)
+}
+
+// Regex creates a rule for the regex custom validator
+func Regex(pattern *regexp.Regexp) *validator.Node {
+	return validation("regex", pattern.String())
+}
+
+// And creates a rule which succeeds if all provided rules succeed
+func And(rules ...*validator.Node) *validator.Node {
+	return chain(validator.NewAndNode, rules)
+}
+
+// Or creates a rule which succeeds if at least one of the provided rules succeeds
+func Or(rules ...*validator.Node) *validator.Node {
+	return chain(validator.NewOrNode, rules)
+}
+
+// Not creates a rule which succeeds if the provided rule fails
+func Not(rule *validator.Node) *validator.Node {
+	return validator.NewNotNode(rule)
+}
+
+// If creates a rule which validates the then rule only if the condition succeeds
+func If(condition, then *validator.Node) *validator.Node {
+	return validator.NewIfNode(condition, then, nil)
+}
+
+// IfElse creates a rule which validates the then rule if the condition succeeds and the otherwise rule if not.
+// An elif statement can be created by passing another If or IfElse rule as otherwise rule.
+func IfElse(condition, then, otherwise *validator.Node) *validator.Node {
+	return validator.NewIfNode(condition, then, otherwise)
+}
+
+// Dive creates a rule which validates the provided rule on every element of a slice, array or map
+func Dive(rule *validator.Node) *validator.Node {
+	return validator.NewDiveNode(rule)
+}
+
+// chain combines the rules by the provided logical operator which is right associative
+func chain(operator func(left, right *validator.Node) *validator.Node, rules []*validator.Node) *validator.Node {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	node := rules[len(rules)-1]
+	for i := len(rules) - 2; i >= 0; i-- {
+		node = operator(rules[i], node)
+	}
+
+	return node
+}
+
+func formatArg(arg interface{}) string {
+	if duration, ok := arg.(time.Duration); ok {
+		return duration.String()
+	}
+
+	value := reflect.ValueOf(arg)
+	switch value.Kind() {
+	case reflect.String:
+		return cv.Quote(value.String())
+	case reflect.Float32:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64)
+	}
+
+	return fmt.Sprint(arg)
+}
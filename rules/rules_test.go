@@ -0,0 +1,82 @@
+package rules
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator"
+)
+
+const maxNameLength = 9
+
+type label string
+
+type person struct {
+	Name   string `validator:"required"`
+	Email  string
+	City   string
+	Emails []string
+}
+
+func TestRules_subTags(t *testing.T) {
+	tests := map[string]*validator.Node{
+		"required":                           Required(),
+		"len(9)":                             Len(maxNameLength),
+		"min(0.5)":                           Min(0.5),
+		"oneof('New York','it\\'s')":         OneOf("New York", "it's"),
+		"regex('^\\\\d+$')":                  Regex(regexp.MustCompile(`^\d+$`)),
+		"max-age(1h30m0s)":                   NewRule1[time.Duration]("max-age")(90 * time.Minute),
+		"between(1,2.5)":                     NewRule2[int, float64]("between")(1, 2.5),
+		"prefix('a','b\\'c')":                NewVariadicRule[label]("prefix")("a", "b'c"),
+		"city":                               NewRule("city")(),
+		"required&&len(9)&&email":            And(Required(), Len(9), Email()),
+		"(email||len(0))&&non-nil":           And(Or(Email(), Len(0)), NonNil()),
+		"!(email||non-zero)":                 Not(Or(Email(), NonZero())),
+		"if(email)then(len(13))":             If(Email(), Len(13)),
+		"if(email)then(len(13))else(len(0))": IfElse(Email(), Len(13), Len(0)),
+		"if(email)then(len(13))elif(non-zero)then(len(4))": IfElse(Email(), Len(13), If(NonZero(), Len(4))),
+		"dive(required&&email)":                            Dive(And(Required(), Email())),
+	}
+
+	for subTag, node := range tests {
+		t.Run(subTag, func(t *testing.T) {
+			assert.Equal(t, subTag, node.SubTag)
+		})
+	}
+}
+
+func TestRules_ValidateStruct(t *testing.T) {
+	v := validator.NewValidator()
+	assert.NoError(t, v.RegisterAlias("city", "oneof('New York', 'Los Angeles')"))
+
+	p := person{Name: "top-level", Email: "test@test.com", City: "New York", Emails: []string{"test@test.com"}}
+
+	err := v.ValidateStruct(context.Background(), &p,
+		validator.Field(&p.Name, Len(maxNameLength)),
+		validator.Field(&p.Email, Or(Email(), Len(0))),
+		validator.Field(&p.City, NewRule("city")()),
+		validator.Field(&p.Emails, Dive(Email())),
+	)
+
+	assert.NoError(t, err)
+}
+
+func TestRules_ValidateStruct_fails(t *testing.T) {
+	v := validator.NewValidator()
+
+	p := person{Name: "top-level", Email: "1234"}
+
+	err := v.ValidateStruct(context.Background(), &p,
+		validator.Field(&p.Email, Or(Email(), Len(0))),
+	)
+	assert.EqualError(t, err, "|| validation of email or len(0) of Field Email failed")
+
+	// tags are validated as well
+	p = person{}
+	err = v.ValidateStruct(context.Background(), &p)
+	assert.Error(t, err)
+}
@@ -0,0 +1,580 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDraft is the meta schema of the schemas created by JSONSchema
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema or subschema that can be serialized by encoding/json
+type Schema map[string]interface{}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// JSONSchema creates a JSON Schema of draft 2020-12 for the struct type of the provided value by the default custom validators.
+// See Validator.JSONSchema for details.
+// Usage:
+//
+//	schema, err := validator.JSONSchema(User{})
+//	data, err := json.Marshal(schema)
+func JSONSchema(i interface{}) (Schema, error) {
+	return NewValidator().JSONSchema(i)
+}
+
+// JSONSchema creates a JSON Schema of draft 2020-12 for the struct type of the provided value.
+// Properties are named like by encoding/json and nested named struct types are defined in $defs.
+// The sub tags of custom validators are mapped by their schema function, sub tags that cannot be expressed are omitted,
+// so the schema may accept more documents than the validation but never less.
+// Logical operators are mapped to allOf, anyOf and not, conditional expressions to if, then and else and dive to items.
+func (v *Validator) JSONSchema(i interface{}) (Schema, error) {
+	rType := reflect.TypeOf(i)
+	for rType != nil && rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+
+	if rType == nil || rType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("json schema of %T is not supported, only structs are supported", i)
+	}
+
+	g := newSchemaGenerator(v, "#/$defs/")
+	g.root = rType
+
+	root, err := g.objectSchema(rType)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := Schema{"$schema": JSONSchemaDraft}
+	if rType.Name() != "" {
+		schema["title"] = rType.Name()
+	}
+	for key, value := range root {
+		schema[key] = value
+	}
+
+	if len(g.defs) > 0 {
+		schema["$defs"] = g.defs
+	}
+
+	return schema, nil
+}
+
+// schemaGenerator creates schemas of struct types whose named nested struct types are referenced by refPrefix and their name
+type schemaGenerator struct {
+	validator *Validator
+	refPrefix string
+	// root is the type of the schema document, which is referenced by # instead of a definition
+	root reflect.Type
+
+	names map[reflect.Type]string
+	defs  map[string]Schema
+}
+
+func newSchemaGenerator(v *Validator, refPrefix string) *schemaGenerator {
+	return &schemaGenerator{
+		validator: v,
+		refPrefix: refPrefix,
+		names:     map[reflect.Type]string{},
+		defs:      map[string]Schema{},
+	}
+}
+
+// typeSchema creates the schema of a Go type as it is encoded by encoding/json.
+// Pointers are nullable since nil pointers are encoded as null.
+func (g *schemaGenerator) typeSchema(rType reflect.Type) (Schema, error) {
+	if rType.Kind() == reflect.Ptr {
+		for rType.Kind() == reflect.Ptr {
+			rType = rType.Elem()
+		}
+
+		schema, err := g.typeSchema(rType)
+		if err != nil {
+			return nil, err
+		}
+		return nullable(schema), nil
+	}
+
+	if rType == timeType {
+		return Schema{"type": "string", "format": "date-time"}, nil
+	}
+
+	switch rType.Kind() {
+	case reflect.Bool:
+		return Schema{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Schema{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}, nil
+	case reflect.String:
+		return Schema{"type": "string"}, nil
+	case reflect.Slice, reflect.Array:
+		if rType.Kind() == reflect.Slice && rType.Elem().Kind() == reflect.Uint8 {
+			return Schema{"type": "string", "contentEncoding": "base64"}, nil
+		}
+
+		items, err := g.typeSchema(rType.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return Schema{"type": "array", "items": items}, nil
+	case reflect.Map:
+		additionalProperties, err := g.typeSchema(rType.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return Schema{"type": "object", "additionalProperties": additionalProperties}, nil
+	case reflect.Struct:
+		if rType.Name() == "" {
+			return g.objectSchema(rType)
+		}
+		return g.ref(rType)
+	}
+
+	// interfaces can contain any value
+	return Schema{}, nil
+}
+
+// nullable extends the schema of a type to accept null
+func nullable(schema Schema) Schema {
+	if len(schema) == 0 {
+		// the schema accepts any value already
+		return schema
+	}
+
+	jsonType, ok := schema["type"].(string)
+	if !ok {
+		return Schema{"anyOf": []Schema{schema, {"type": "null"}}}
+	}
+
+	extended := Schema{}
+	for key, value := range schema {
+		extended[key] = value
+	}
+	extended["type"] = []string{jsonType, "null"}
+
+	return extended
+}
+
+// ref returns a reference to the definition of a named struct type which is created on its first reference
+func (g *schemaGenerator) ref(structType reflect.Type) (Schema, error) {
+	if structType == g.root {
+		return Schema{"$ref": "#"}, nil
+	}
+
+	name, ok := g.names[structType]
+	if !ok {
+		name = g.defName(structType)
+		g.names[structType] = name
+
+		def, err := g.objectSchema(structType)
+		if err != nil {
+			return nil, err
+		}
+		g.defs[name] = def
+	}
+
+	return Schema{"$ref": g.refPrefix + name}, nil
+}
+
+// defName returns the name of a type which is qualified by its package path if another type of the same name exists
+func (g *schemaGenerator) defName(structType reflect.Type) string {
+	name := structType.Name()
+	for _, existing := range g.names {
+		if existing == name {
+			return strings.ReplaceAll(structType.PkgPath(), "/", ".") + "." + name
+		}
+	}
+
+	return name
+}
+
+// objectSchema creates the schema of a struct type including the constraints of the tags of its fields
+func (g *schemaGenerator) objectSchema(structType reflect.Type) (Schema, error) {
+	properties := Schema{}
+	var required []string
+
+	err := g.addProperties(structType, properties, &required)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := Schema{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return schema, nil
+}
+
+func (g *schemaGenerator) addProperties(structType reflect.Type, properties Schema, required *[]string) error {
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+
+		name, ok := jsonName(structField)
+		if !ok {
+			continue
+		}
+
+		fieldType := structField.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		// fields of embedded structs are promoted to the embedding struct unless the embedded struct is named by a json tag
+		if structField.Anonymous && fieldType.Kind() == reflect.Struct && structField.Tag.Get("json") == "" {
+			err := g.addProperties(fieldType, properties, required)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !structField.IsExported() {
+			continue
+		}
+
+		schema, isRequired, err := g.fieldSchema(structType, structField)
+		if err != nil {
+			return err
+		}
+
+		properties[name] = schema
+		if isRequired {
+			*required = append(*required, name)
+		}
+	}
+
+	return nil
+}
+
+// fieldSchema creates the schema of a struct field and reports whether its tags require the field
+func (g *schemaGenerator) fieldSchema(structType reflect.Type, structField reflect.StructField) (Schema, bool, error) {
+	node, err := g.validator.compileField(structType, structField)
+	if err != nil {
+		return nil, false, withPath(err, fmt.Sprintf("%v.%v", structType.Name(), structField.Name))
+	}
+
+	constraint := schemaConstraint{}
+	if node != nil {
+		constraint, err = g.nodeSchema(node, structField.Type)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	// required pointers are not nullable since their validation fails for nil
+	fieldType := structField.Type
+	if constraint.required {
+		fieldType = getUnderlyingType(fieldType)
+	}
+
+	schema, err := g.typeSchema(fieldType)
+	if err != nil {
+		return nil, false, err
+	}
+
+	schema = mergeSchemas(schema, constraint.schema)
+	required := constraint.required
+
+	annotations, err := schemaAnnotations(structField)
+	if err != nil {
+		return nil, false, fmt.Errorf("field %v.%v: %w", structType.Name(), structField.Name, err)
+	}
+
+	if len(annotations) > 0 {
+		schema = mergeSchemas(annotations, schema)
+	}
+
+	return schema, required, nil
+}
+
+// schemaAnnotations creates a description from the doc tag and examples from the example tag of a struct field.
+// Examples of fields which are not strings are parsed as JSON e.g. example:"[1, 2]" for a slice of ints.
+func schemaAnnotations(structField reflect.StructField) (Schema, error) {
+	annotations := Schema{}
+
+	if doc, ok := structField.Tag.Lookup("doc"); ok {
+		annotations["description"] = doc
+	}
+
+	example, ok := structField.Tag.Lookup("example")
+	if !ok {
+		return annotations, nil
+	}
+
+	fieldType := structField.Type
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType.Kind() == reflect.String {
+		annotations["examples"] = []interface{}{example}
+		return annotations, nil
+	}
+
+	err := json.Unmarshal([]byte(example), reflect.New(fieldType).Interface())
+	if err != nil {
+		return nil, fmt.Errorf("example %q cannot be parsed: %w", example, err)
+	}
+
+	var value interface{}
+	err = json.Unmarshal([]byte(example), &value)
+	if err != nil {
+		return nil, fmt.Errorf("example %q cannot be parsed: %w", example, err)
+	}
+
+	annotations["examples"] = []interface{}{value}
+	return annotations, nil
+}
+
+// schemaConstraint is the schema of an expression tree
+type schemaConstraint struct {
+	schema Schema
+	// required reports whether the expression requires the property to be present
+	required bool
+	// exact reports whether the schema expresses the whole expression.
+	// Inexact schemas are less strict than the expression since unknown validations are omitted.
+	exact bool
+}
+
+// nodeSchema creates the schema of an expression tree for a field of the provided type
+func (g *schemaGenerator) nodeSchema(node *Node, fieldType reflect.Type) (schemaConstraint, error) {
+	switch node.Kind {
+	case AndNode:
+		left, right, err := g.childSchemas(node, fieldType)
+		if err != nil {
+			return schemaConstraint{}, err
+		}
+
+		return schemaConstraint{
+			schema:   mergeSchemas(left.schema, right.schema),
+			required: left.required || right.required,
+			exact:    left.exact && right.exact,
+		}, nil
+	case OrNode:
+		left, right, err := g.childSchemas(node, fieldType)
+		if err != nil || !left.exact || !right.exact {
+			return schemaConstraint{}, err
+		}
+
+		constraint := schemaConstraint{required: left.required && right.required, exact: true}
+		if len(left.schema) > 0 && len(right.schema) > 0 {
+			constraint.schema = Schema{"anyOf": []Schema{left.schema, right.schema}}
+		}
+		return constraint, nil
+	case NotNode:
+		operand, err := g.nodeSchema(node.Children[0], fieldType)
+		if err != nil || !operand.exact || len(operand.schema) == 0 {
+			// the absence of a property cannot be expressed
+			return schemaConstraint{}, err
+		}
+
+		return schemaConstraint{schema: Schema{"not": operand.schema}, exact: true}, nil
+	case IfNode:
+		return g.ifSchema(node, fieldType)
+	case DiveNode:
+		return g.diveSchema(node, fieldType)
+	case AliasNode:
+		return g.nodeSchema(node.Children[0], fieldType)
+	}
+
+	return g.validationSchema(node, fieldType), nil
+}
+
+func (g *schemaGenerator) childSchemas(node *Node, fieldType reflect.Type) (schemaConstraint, schemaConstraint, error) {
+	left, err := g.nodeSchema(node.Children[0], fieldType)
+	if err != nil {
+		return schemaConstraint{}, schemaConstraint{}, err
+	}
+
+	right, err := g.nodeSchema(node.Children[1], fieldType)
+	if err != nil {
+		return schemaConstraint{}, schemaConstraint{}, err
+	}
+
+	return left, right, nil
+}
+
+func (g *schemaGenerator) validationSchema(node *Node, fieldType reflect.Type) schemaConstraint {
+	constraint := schemaConstraint{exact: len(node.bindings) > 0}
+
+	for _, b := range node.bindings {
+		if b.customValidator.Schema == nil {
+			constraint.exact = false
+			continue
+		}
+
+		fragment := b.customValidator.Schema(getUnderlyingType(fieldType).Kind(), b.validationCtx)
+		if fragment == nil {
+			constraint.exact = false
+			continue
+		}
+
+		constraint.schema = mergeSchemas(constraint.schema, Schema(fragment.Keywords))
+		constraint.required = constraint.required || fragment.Required
+
+		// the property of a nil pointer is absent, which is required if the validation fails for nil
+		if fieldType.Kind() == reflect.Ptr {
+			if ptrFragment := b.customValidator.Schema(reflect.Ptr, b.validationCtx); ptrFragment != nil {
+				constraint.required = constraint.required || ptrFragment.Required
+			}
+		}
+	}
+
+	return constraint
+}
+
+func (g *schemaGenerator) ifSchema(node *Node, fieldType reflect.Type) (schemaConstraint, error) {
+	condition, err := g.nodeSchema(node.Children[0], fieldType)
+	if err != nil || !condition.exact {
+		return schemaConstraint{}, err
+	}
+
+	then, err := g.nodeSchema(node.Children[1], fieldType)
+	if err != nil {
+		return schemaConstraint{}, err
+	}
+
+	if len(condition.schema) == 0 {
+		// the condition is fulfilled by every present value
+		then.required = false
+		return then, nil
+	}
+
+	schema := Schema{"if": condition.schema}
+	if len(then.schema) > 0 {
+		schema["then"] = then.schema
+	}
+
+	exact := then.exact
+	if len(node.Children) > 2 {
+		otherwise, err := g.nodeSchema(node.Children[2], fieldType)
+		if err != nil {
+			return schemaConstraint{}, err
+		}
+
+		if len(otherwise.schema) > 0 {
+			schema["else"] = otherwise.schema
+		}
+		exact = exact && otherwise.exact
+	}
+
+	return schemaConstraint{schema: schema, exact: exact}, nil
+}
+
+func (g *schemaGenerator) diveSchema(node *Node, fieldType reflect.Type) (schemaConstraint, error) {
+	fieldType = getUnderlyingType(fieldType)
+
+	var keyword string
+	switch fieldType.Kind() {
+	case reflect.Slice, reflect.Array:
+		keyword = "items"
+	case reflect.Map:
+		keyword = "additionalProperties"
+	default:
+		return schemaConstraint{}, nil
+	}
+
+	elem, err := g.nodeSchema(node.Children[0], fieldType.Elem())
+	if err != nil {
+		return schemaConstraint{}, err
+	}
+
+	elemSchema, err := g.typeSchema(fieldType.Elem())
+	if err != nil {
+		return schemaConstraint{}, err
+	}
+
+	return schemaConstraint{
+		schema: Schema{keyword: mergeSchemas(elemSchema, elem.schema)},
+		exact:  elem.exact,
+	}, nil
+}
+
+// mergeSchemas combines the keywords of two schemas or creates an allOf schema if they contain conflicting keywords
+func mergeSchemas(a, b Schema) Schema {
+	if len(a) == 0 {
+		return b
+	}
+
+	if len(b) == 0 {
+		return a
+	}
+
+	merged := Schema{}
+	for key, value := range a {
+		merged[key] = value
+	}
+
+	for key, value := range b {
+		existing, ok := merged[key]
+		if !ok {
+			merged[key] = value
+			continue
+		}
+
+		existingSchema, existingOk := existing.(Schema)
+		valueSchema, valueOk := value.(Schema)
+		existingBound, existingIsBound := schemaBound(existing)
+		valueBound, valueIsBound := schemaBound(value)
+		switch {
+		case reflect.DeepEqual(existing, value):
+		case existingOk && valueOk && (key == "items" || key == "additionalProperties"):
+			merged[key] = mergeSchemas(existingSchema, valueSchema)
+		case existingIsBound && valueIsBound && lowerBoundKeywords[key]:
+			if valueBound > existingBound {
+				merged[key] = value
+			}
+		case existingIsBound && valueIsBound && upperBoundKeywords[key]:
+			if valueBound < existingBound {
+				merged[key] = value
+			}
+		default:
+			return Schema{"allOf": []Schema{a, b}}
+		}
+	}
+
+	return merged
+}
+
+// lowerBoundKeywords and upperBoundKeywords are merged by their stricter value instead of an allOf schema
+var (
+	lowerBoundKeywords = map[string]bool{"minimum": true, "minLength": true, "minItems": true, "minProperties": true}
+	upperBoundKeywords = map[string]bool{"maximum": true, "maxLength": true, "maxItems": true, "maxProperties": true}
+)
+
+func schemaBound(value interface{}) (float64, bool) {
+	switch bound := value.(type) {
+	case int:
+		return float64(bound), true
+	case float64:
+		return bound, true
+	}
+
+	return 0, false
+}
+
+// jsonName returns the name of a struct field as encoded by encoding/json or false if it is omitted
+func jsonName(structField reflect.StructField) (string, bool) {
+	tag := structField.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+
+	name := tag
+	if i := strings.Index(tag, ","); i >= 0 {
+		name = tag[:i]
+	}
+
+	if name == "" {
+		name = structField.Name
+	}
+
+	return name, true
+}
@@ -0,0 +1,151 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+	"github.com/stretchr/testify/assert"
+)
+
+type SchemaUser struct {
+	Name      string            `json:"name" validator:"required && len(9)"`
+	Email     string            `json:"email,omitempty" validator:"email || len(0)"`
+	Age       int               `json:"age" validator:"min(18) && max(130)"`
+	Role      string            `json:"role" validator:"oneof(admin,user)"`
+	Nickname  *string           `json:"nickname" validator:"if(non-nil) then(min(3)) else(non-nil)"`
+	Tags      []string          `json:"tags" validator:"max(3) && dive(regex(^[a-z]+$))"`
+	Labels    map[string]string `json:"labels" validator:"dive(non-zero)"`
+	Address   *SchemaAddress    `json:"address" validator:"non-nil"`
+	Friends   []*SchemaUser     `json:"friends"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Avatar    []byte            `json:"avatar"`
+	Secret    string            `json:"-" validator:"required"`
+	internal  string
+	SchemaEmbedded
+}
+
+type SchemaAddress struct {
+	Street string `validator:"non-zero"`
+	Zip    int    `validator:"!len(5)"`
+}
+
+type SchemaEmbedded struct {
+	Active bool `json:"active" validator:"non-zero"`
+}
+
+func TestValidator_JSONSchema(t *testing.T) {
+	schema, err := JSONSchema(&SchemaUser{internal: "ignored"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	data, err := json.Marshal(schema)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "SchemaUser",
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "minLength": 1, "maxLength": 9},
+			"email": {"type": "string", "anyOf": [{"format": "email"}, {"maxLength": 0}]},
+			"age": {"type": "integer", "minimum": 18, "maximum": 130},
+			"role": {"type": "string", "enum": ["admin", "user"]},
+			"nickname": {"type": ["string", "null"]},
+			"tags": {"type": "array", "maxItems": 3, "items": {"type": "string", "pattern": "^[a-z]+$"}},
+			"labels": {"type": "object", "additionalProperties": {"type": "string", "minLength": 1}},
+			"address": {"$ref": "#/$defs/SchemaAddress"},
+			"friends": {"type": "array", "items": {"anyOf": [{"$ref": "#"}, {"type": "null"}]}},
+			"createdAt": {"type": "string", "format": "date-time"},
+			"avatar": {"type": "string", "contentEncoding": "base64"},
+			"active": {"type": "boolean", "const": true}
+		},
+		"required": ["name", "address", "active"],
+		"$defs": {
+			"SchemaAddress": {
+				"type": "object",
+				"properties": {
+					"Street": {"type": "string", "minLength": 1},
+					"Zip": {"type": "integer"}
+				},
+				"required": ["Street"]
+			}
+		}
+	}`, string(data))
+}
+
+func TestValidator_JSONSchema_mergesConflictingKeywords(t *testing.T) {
+	type Conflicting struct {
+		Code string `validator:"regex(^[A-Z]+$) && regex(^.{3}$)"`
+	}
+
+	schema, err := JSONSchema(Conflicting{})
+	assert.NoError(t, err)
+	assert.Equal(t, Schema{
+		"type": "string",
+		"allOf": []Schema{
+			{"pattern": "^[A-Z]+$"},
+			{"pattern": "^.{3}$"},
+		},
+	}, schema["properties"].(Schema)["Code"])
+}
+
+func TestValidator_JSONSchema_requiresNonNilPropertiesOfNilableFields(t *testing.T) {
+	type NonNil struct {
+		Name   string            `json:"name" validator:"non-nil"`
+		Count  *int              `json:"count" validator:"non-nil"`
+		Labels map[string]string `json:"labels" validator:"non-nil"`
+	}
+
+	schema, err := JSONSchema(NonNil{})
+	assert.NoError(t, err)
+	// absent strings decode to the empty string, which is not nil
+	assert.Equal(t, []string{"count", "labels"}, schema["required"])
+	assert.Equal(t, Schema{"type": "integer"}, schema["properties"].(Schema)["count"])
+}
+
+func TestValidator_JSONSchema_omitsUnknownValidators(t *testing.T) {
+	validator := NewValidator()
+	validator.RegisterCustomValidator(cv.NewCustomValidator("even", regexp.MustCompile(`^even$`), func(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
+		return nil
+	}, cv.NewCustomValidatorConfig()))
+	validator.RegisterCustomValidator(cv.NewCustomValidator("positive", regexp.MustCompile(`^positive$`), func(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
+		return nil
+	}, cv.NewCustomValidatorConfig()).WithSchema(func(kind reflect.Kind, validationCtx *cv.ValidationContext) *cv.SchemaFragment {
+		return &cv.SchemaFragment{Keywords: map[string]interface{}{"exclusiveMinimum": 0}}
+	}))
+
+	type Numbers struct {
+		And int `validator:"even && positive"`
+		Or  int `validator:"even || positive"`
+		Not int `validator:"!even"`
+		If  int `validator:"if(even) then(positive)"`
+	}
+
+	schema, err := validator.JSONSchema(Numbers{})
+	assert.NoError(t, err)
+	assert.Equal(t, Schema{
+		"And": Schema{"type": "integer", "exclusiveMinimum": 0},
+		"Or":  Schema{"type": "integer"},
+		"Not": Schema{"type": "integer"},
+		"If":  Schema{"type": "integer"},
+	}, schema["properties"])
+}
+
+func TestValidator_JSONSchema_fails(t *testing.T) {
+	_, err := JSONSchema("no struct")
+	assert.EqualError(t, err, "json schema of string is not supported, only structs are supported")
+
+	_, err = JSONSchema(nil)
+	assert.EqualError(t, err, "json schema of <nil> is not supported, only structs are supported")
+
+	type Invalid struct {
+		Name string `validator:"len(9"`
+	}
+	_, err = JSONSchema(Invalid{})
+	assert.EqualError(t, err, "missing closing bracket: map[field-path:Invalid.Name position:5 tag:len(9]")
+}
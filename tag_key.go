@@ -0,0 +1,121 @@
+package validator
+
+import (
+	"reflect"
+)
+
+// DefaultTagKey is the struct field tag key that is used if no tag keys are configured on the validator
+const DefaultTagKey = "validator"
+
+// MessageTagKey is the struct field tag key containing a message that replaces the message of the error of the whole field,
+// e.g. `validator:"len(9)" validator-msg:"username.length"`
+const MessageTagKey = "validator-msg"
+
+// TagSyntax defines the syntax that is used to parse the value of a struct field tag
+type TagSyntax int
+
+const (
+	// NativeSyntax is the syntax of this module e.g. `validator:"required && len(9)"`
+	NativeSyntax TagSyntax = iota
+	// PlaygroundSyntax is the comma separated syntax of github.com/go-playground/validator e.g. `validate:"required,len=9"`
+	PlaygroundSyntax
+)
+
+// TagKey defines a struct field tag key containing validation rules and the syntax the rules are written in
+type TagKey struct {
+	Name   string
+	Syntax TagSyntax
+}
+
+// NativeTagKey creates a new tag key of the native syntax
+func NativeTagKey(name string) TagKey {
+	return TagKey{
+		Name:   name,
+		Syntax: NativeSyntax,
+	}
+}
+
+// PlaygroundTagKey creates a new tag key of the go-playground compatible syntax
+func PlaygroundTagKey(name string) TagKey {
+	return TagKey{
+		Name:   name,
+		Syntax: PlaygroundSyntax,
+	}
+}
+
+// SetTagKeys configures the struct field tag keys that are read by the validator.
+// If a field contains multiple of the configured keys, the validations of all keys have to succeed.
+// Usage:
+//
+//	validator := NewValidator()
+//	validator.SetTagKeys(NativeTagKey("validator"), PlaygroundTagKey("validate"))
+func (v *Validator) SetTagKeys(tagKeys ...TagKey) {
+	v.TagKeys = tagKeys
+}
+
+func (v *Validator) tagKeys() []TagKey {
+	if len(v.TagKeys) == 0 {
+		return []TagKey{NativeTagKey(DefaultTagKey)}
+	}
+
+	return v.TagKeys
+}
+
+// HasTagKey returns whether a struct field tag contains any of the tag keys of the validator,
+// which defaults to the DefaultTagKey if no tag keys have been configured
+func (v *Validator) HasTagKey(tag reflect.StructTag) bool {
+	for _, tagKey := range v.tagKeys() {
+		if _, ok := tag.Lookup(tagKey.Name); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseFieldTags parses the tags of all configured tag keys of a struct field into a single expression tree.
+// Returns nil if the struct field does not contain any validation or is ignored by an IgnoreTag.
+func (v *Validator) parseFieldTags(structField reflect.StructField) (*Node, error) {
+	if v.isIgnored(structField) {
+		return nil, nil
+	}
+
+	var node *Node
+
+	tagKeys := v.tagKeys()
+	for i := len(tagKeys) - 1; i >= 0; i-- {
+		tag, ok := structField.Tag.Lookup(tagKeys[i].Name)
+		if !ok {
+			continue
+		}
+
+		var keyNode *Node
+		var err error
+		switch tagKeys[i].Syntax {
+		case PlaygroundSyntax:
+			keyNode, err = parsePlaygroundTag(tag)
+		default:
+			keyNode, err = parseTag(tag)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if keyNode == nil {
+			continue
+		}
+
+		if node == nil {
+			node = keyNode
+		} else {
+			node = NewAndNode(keyNode, node)
+		}
+	}
+
+	if message, ok := structField.Tag.Lookup(MessageTagKey); ok && node != nil && message != "" {
+		node.Message = message
+	}
+
+	return node, nil
+}
@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type PlaygroundStruct struct {
+	Name  string   `validate:"required,len=9"`
+	Email string   `validate:"omitempty,email"`
+	Tags  []string `validate:"max=2,dive,required,min=2"`
+}
+
+type MultiKeyStruct struct {
+	Field string `validator:"email" validate:"len=13"`
+}
+
+func TestValidator_Validate_playgroundTagKey(t *testing.T) {
+	validator := NewValidator()
+	validator.SetTagKeys(PlaygroundTagKey("validate"))
+
+	err := validator.Validate(context.Background(), PlaygroundStruct{Name: "top-level", Tags: []string{"ab", "cd"}})
+	assert.NoError(t, err)
+
+	err = validator.Validate(context.Background(), PlaygroundStruct{Name: "top-level", Email: ValidEmail})
+	assert.NoError(t, err)
+
+	invalid := []PlaygroundStruct{
+		{Name: "short"},
+		{Name: "top-level", Email: InvalidEmail},
+		{Name: "top-level", Tags: []string{"ab", "cd", "ef"}},
+		{Name: "top-level", Tags: []string{"ab", "c"}},
+	}
+
+	for _, val := range invalid {
+		err := validator.Validate(context.Background(), val)
+		assert.Error(t, err)
+	}
+}
+
+func TestValidator_Validate_multipleTagKeys(t *testing.T) {
+	validator := NewValidator()
+	validator.SetTagKeys(NativeTagKey(DefaultTagKey), PlaygroundTagKey("validate"))
+
+	err := validator.Validate(context.Background(), MultiKeyStruct{Field: ValidEmail})
+	assert.NoError(t, err)
+
+	err = validator.Validate(context.Background(), MultiKeyStruct{Field: "test@test.de"})
+	assert.Error(t, err)
+
+	err = validator.Validate(context.Background(), MultiKeyStruct{Field: "1234567890123"})
+	assert.Error(t, err)
+}
+
+func TestValidator_Validate_ignoresUnconfiguredTagKeys(t *testing.T) {
+	validator := NewValidator()
+
+	err := validator.Validate(context.Background(), PlaygroundStruct{})
+
+	assert.NoError(t, err)
+}
+
+func TestValidator_HasTagKey(t *testing.T) {
+	v := NewValidator()
+	assert.True(t, v.HasTagKey(`validator:"required"`))
+	assert.True(t, v.HasTagKey(`json:"name" validator:""`))
+	assert.False(t, v.HasTagKey(`validate:"required"`))
+
+	v.SetTagKeys(PlaygroundTagKey("validate"))
+	assert.True(t, v.HasTagKey(`validate:"required"`))
+	assert.False(t, v.HasTagKey(`validator:"required"`))
+}
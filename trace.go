@@ -0,0 +1,222 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// Branches of if nodes in traces
+const (
+	// ThenBranch is taken if the condition of an if node succeeds
+	ThenBranch = "then"
+	// ElseBranch is taken if the condition of an if node fails and it has an elif or else statement
+	ElseBranch = "else"
+	// NoBranch is taken if the condition of an if node fails and it has neither an elif nor an else statement
+	NoBranch = "none"
+)
+
+// Trace contains the evaluation trees of the fields of a traced validation
+type Trace struct {
+	// Type is the name of the validated type e.g. main.User
+	Type   string        `json:"type"`
+	Fields []*FieldTrace `json:"fields"`
+}
+
+// FieldTrace contains the evaluation trees of the tags of a field
+type FieldTrace struct {
+	// Path is the full name of the field e.g. Address.Street
+	Path string `json:"path"`
+	// Nodes contains the evaluation tree of the tags of the field
+	Nodes []*NodeTrace `json:"nodes"`
+}
+
+// Valid returns whether the evaluation trees of the field succeeded
+func (fieldTrace *FieldTrace) Valid() bool {
+	for _, node := range fieldTrace.Nodes {
+		if node.Err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NodeTrace is the evaluation of a node of an expression tree
+type NodeTrace struct {
+	Kind   NodeKind `json:"kind"`
+	SubTag string   `json:"subTag"`
+	// Path is the full name of the element a node has been evaluated on by a dive node e.g. Tags[0] or empty for the field itself
+	Path string `json:"path,omitempty"`
+	// Branch is the branch taken by an if node or empty for all other nodes
+	Branch string `json:"branch,omitempty"`
+	// Err is the error of the node or nil if it succeeded
+	Err      error         `json:"-"`
+	Duration time.Duration `json:"duration"`
+	// Children are the evaluations of the children of the node. Dive nodes contain an evaluation per element.
+	Children []*NodeTrace `json:"children,omitempty"`
+}
+
+// MarshalJSON marshals the node with its validity and error message.
+// Implements json.Marshaler interface
+func (nodeTrace *NodeTrace) MarshalJSON() ([]byte, error) {
+	type plain NodeTrace
+
+	errMessage := ""
+	if nodeTrace.Err != nil {
+		errMessage = nodeTrace.Err.Error()
+	}
+
+	return json.Marshal(struct {
+		*plain
+		Valid bool   `json:"valid"`
+		Error string `json:"error,omitempty"`
+	}{(*plain)(nodeTrace), nodeTrace.Err == nil, errMessage})
+}
+
+// Trace validates the provided interface{} like ValidateAll and records how the tags of every field have been evaluated.
+// Returns the trace and the error of the validation, which can be rendered as indented text by String or as JSON for debugging.
+// Usage:
+//
+//	trace, err := validator.Trace(ctx, user)
+//	fmt.Println(trace)
+func (v *Validator) Trace(ctx context.Context, i interface{}) (*Trace, error) {
+	trace := &Trace{Type: "<nil>"}
+	if rType := reflect.TypeOf(i); rType != nil {
+		trace.Type = getUnderlyingType(rType).String()
+	}
+
+	tracer := *v
+	tracer.tracing = true
+
+	err := tracer.ValidateAll(context.WithValue(ctx, traceKey{}, trace), i)
+	return trace, err
+}
+
+type traceKey struct{}
+
+type traceScopeKey struct{}
+
+// traceScope contains the evaluations of the children of the node or field that is evaluated
+type traceScope struct {
+	nodes *[]*NodeTrace
+	path  string
+}
+
+// traceField starts the trace of a field and returns the context the tags and rules of the field are evaluated with
+func (v *Validator) traceField(ctx context.Context, field *cv.Field) context.Context {
+	trace, ok := ctx.Value(traceKey{}).(*Trace)
+	if !v.tracing || !ok {
+		return ctx
+	}
+
+	fieldTrace := &FieldTrace{Path: getFullFieldName(field)}
+	trace.Fields = append(trace.Fields, fieldTrace)
+
+	return context.WithValue(ctx, traceScopeKey{}, &traceScope{nodes: &fieldTrace.Nodes, path: fieldTrace.Path})
+}
+
+// traceNode evaluates a node and records the evaluation in the trace of the field.
+// Returns false if the evaluation is not traced and the node has not been evaluated.
+func (v *Validator) traceNode(ctx context.Context, field *cv.Field, node *Node) (bool, error) {
+	if !v.tracing {
+		return false, nil
+	}
+
+	scope, ok := ctx.Value(traceScopeKey{}).(*traceScope)
+	if !ok {
+		return false, nil
+	}
+
+	nodeTrace := &NodeTrace{Kind: node.Kind, SubTag: node.SubTag}
+	*scope.nodes = append(*scope.nodes, nodeTrace)
+
+	path := getFullFieldName(field)
+	if path != scope.path {
+		nodeTrace.Path = path
+	}
+
+	ctx = context.WithValue(ctx, traceScopeKey{}, &traceScope{nodes: &nodeTrace.Children, path: path})
+
+	start := time.Now()
+	err := v.evaluateNode(ctx, field, node)
+	if err != nil && node.Message != "" {
+		err = withMessage(err, field, node)
+	}
+	nodeTrace.Duration = time.Since(start)
+	nodeTrace.Err = err
+
+	if node.Kind == IfNode {
+		switch {
+		case nodeTrace.Children[0].Err == nil:
+			nodeTrace.Branch = ThenBranch
+		case len(node.Children) > 2:
+			nodeTrace.Branch = ElseBranch
+		default:
+			nodeTrace.Branch = NoBranch
+		}
+	}
+
+	return true, err
+}
+
+// WriteTo writes the trace as indented text containing a line per field and node with its result, the branch of if nodes,
+// the duration and the error of validation nodes.
+// Implements io.WriterTo interface
+func (trace *Trace) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintln(&buf, trace.Type)
+	for _, fieldTrace := range trace.Fields {
+		fmt.Fprintf(&buf, "  %v: %v\n", fieldTrace.Path, validity(fieldTrace.Valid()))
+		for _, nodeTrace := range fieldTrace.Nodes {
+			writeNodeTrace(&buf, nodeTrace, 2)
+		}
+	}
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+func writeNodeTrace(buf *bytes.Buffer, nodeTrace *NodeTrace, depth int) {
+	buf.WriteString(strings.Repeat("  ", depth))
+	if nodeTrace.Path != "" {
+		fmt.Fprintf(buf, "%v: ", nodeTrace.Path)
+	}
+	fmt.Fprintf(buf, "%v [%v] %v", nodeTrace.SubTag, nodeTrace.Kind, validity(nodeTrace.Err == nil))
+	if nodeTrace.Branch != "" {
+		fmt.Fprintf(buf, ", branch %v", nodeTrace.Branch)
+	}
+	fmt.Fprintf(buf, " (%v)", nodeTrace.Duration)
+	if nodeTrace.Err != nil && nodeTrace.Kind == ValidationNode {
+		fmt.Fprintf(buf, ": %v", nodeTrace.Err)
+	}
+	buf.WriteString("\n")
+
+	for _, child := range nodeTrace.Children {
+		writeNodeTrace(buf, child, depth+1)
+	}
+}
+
+func validity(valid bool) string {
+	if valid {
+		return "valid"
+	}
+
+	return "invalid"
+}
+
+// String returns the trace as indented text
+func (trace *Trace) String() string {
+	var builder strings.Builder
+	_, _ = trace.WriteTo(&builder)
+
+	return builder.String()
+}
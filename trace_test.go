@@ -0,0 +1,100 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TracedUser struct {
+	Contact string   `validator:"if(email)then(len(28)) elif(len(0))then(len(0)) else(len(10))"`
+	Name    string   `validator:"len(0) || len(3)"`
+	Tags    []string `validator:"dive(len(2))"`
+	Note    string
+}
+
+// durations removes the durations from a trace rendered as text
+var durations = regexp.MustCompile(` \([^()]*\)`)
+
+func TestValidator_Trace(t *testing.T) {
+	validator := NewValidator()
+
+	trace, err := validator.Trace(context.Background(), &TracedUser{Contact: "a@b.c", Name: "abc", Tags: []string{"ab", "abc"}})
+	assert.Error(t, err)
+	assert.Equal(t, "validator.TracedUser", trace.Type)
+
+	expected := `validator.TracedUser
+  Contact: invalid
+    if(email)then(len(28))elif(len(0))then(len(0))else(len(10)) [if] invalid, branch then
+      email [validation] valid
+      len(28) [validation] invalid: len field Contact has length 5, but should have length 28
+  Name: valid
+    len(0)||len(3) [or] valid
+      len(0) [validation] invalid: len field Name has length 3, but should have length 0
+      len(3) [validation] valid
+  Tags: invalid
+    dive(len(2)) [dive] invalid
+      Tags[0]: len(2) [validation] valid
+      Tags[1]: len(2) [validation] invalid: len field Tags[1] has length 3, but should have length 2
+`
+	assert.Equal(t, expected, durations.ReplaceAllString(trace.String(), ""))
+
+	trace, err = validator.Trace(context.Background(), TracedUser{Contact: "abc"})
+	assert.Error(t, err)
+	// the branches that have not been taken are not evaluated
+	contact := trace.Fields[0].Nodes[0]
+	assert.Equal(t, ElseBranch, contact.Branch)
+	if assert.Len(t, contact.Children, 2) {
+		assert.Equal(t, IfNode, contact.Children[1].Kind)
+		assert.Equal(t, ElseBranch, contact.Children[1].Branch)
+		assert.Equal(t, "len(10)", contact.Children[1].Children[1].SubTag)
+	}
+
+	trace, err = validator.Trace(context.Background(), struct {
+		Name string `validator:"if(len(3))then(email)"`
+	}{Name: "ab"})
+	assert.NoError(t, err)
+	assert.Equal(t, NoBranch, trace.Fields[0].Nodes[0].Branch)
+}
+
+func TestValidator_Trace_json(t *testing.T) {
+	validator := NewValidator()
+	user := TracedUser{Name: "abc", Note: "x"}
+
+	trace, err := validator.Trace(context.Background(), &user)
+	assert.NoError(t, err)
+	assert.Len(t, trace.Fields, 3)
+
+	raw, err := json.Marshal(trace)
+	assert.NoError(t, err)
+
+	var decoded struct {
+		Type   string `json:"type"`
+		Fields []struct {
+			Path  string `json:"path"`
+			Nodes []struct {
+				Kind     string `json:"kind"`
+				SubTag   string `json:"subTag"`
+				Branch   string `json:"branch"`
+				Valid    bool   `json:"valid"`
+				Error    string `json:"error"`
+				Children []struct {
+					Valid bool   `json:"valid"`
+					Error string `json:"error"`
+				} `json:"children"`
+			} `json:"nodes"`
+		} `json:"fields"`
+	}
+	assert.NoError(t, json.Unmarshal(raw, &decoded))
+	assert.Equal(t, "validator.TracedUser", decoded.Type)
+	assert.Equal(t, "Contact", decoded.Fields[0].Path)
+	assert.Equal(t, "if", decoded.Fields[0].Nodes[0].Kind)
+	assert.Equal(t, "else", decoded.Fields[0].Nodes[0].Branch)
+	assert.True(t, decoded.Fields[0].Nodes[0].Valid)
+	assert.False(t, decoded.Fields[1].Nodes[0].Children[0].Valid)
+	assert.Equal(t, "len field Name has length 3, but should have length 0", decoded.Fields[1].Nodes[0].Children[0].Error)
+	assert.Empty(t, decoded.Fields[1].Nodes[0].Error)
+}
@@ -0,0 +1,265 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+// Catalog contains the message templates of a locale
+type Catalog struct {
+	// Messages contains a text/template per custom validator ID or operator kind e.g. or and not.
+	// The templates are executed with MessageData e.g. "{{.Label}} must have exactly {{index .Params 0}} {{.Unit}}".
+	// Errors of operators without template are translated by the message of their cause.
+	Messages map[string]string
+	// Units contains the translations of the units of lengths: characters, elements and entries
+	Units map[string]string
+}
+
+// MessageData is passed to the message templates of a catalog
+type MessageData struct {
+	// Label is the display name of the field from its label tag or the name of the field
+	Label string
+	// Path is the full name of the field e.g. Address.Street
+	Path string
+	// Params contains the arguments of the failed sub tag
+	Params []string
+	// Value is the dereferenced value of the field or nil
+	Value interface{}
+	// Kind is the kind of the dereferenced field e.g. string
+	Kind string
+	// Unit is the translated unit of the length of the field: characters for strings, elements for slices and arrays,
+	// entries for maps and empty for all other kinds
+	Unit string
+}
+
+// messageFuncs are the functions which can be used by message templates in addition to the builtin functions
+var messageFuncs = template.FuncMap{
+	"join": strings.Join,
+}
+
+type localeKey struct{}
+
+// WithLocale returns a context that selects the locale of the messages of validation errors e.g. de or en-US.
+// Errors of validations using the context are translated by the catalog of the locale.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFromContext returns the locale that has been set by WithLocale
+func LocaleFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+
+	locale, ok := ctx.Value(localeKey{}).(string)
+	return locale, ok
+}
+
+// compiledCatalog contains the parsed templates of a catalog
+type compiledCatalog struct {
+	messages map[string]*template.Template
+	units    map[string]string
+}
+
+func compileCatalog(locale string, catalog Catalog) (*compiledCatalog, error) {
+	compiled := &compiledCatalog{
+		messages: map[string]*template.Template{},
+		units:    map[string]string{},
+	}
+
+	for unit, translation := range catalog.Units {
+		compiled.units[unit] = translation
+	}
+
+	for id, text := range catalog.Messages {
+		tmpl, err := template.New(id).Funcs(messageFuncs).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("message %v of locale %v cannot be parsed: %w", id, locale, err)
+		}
+		compiled.messages[id] = tmpl
+	}
+
+	return compiled, nil
+}
+
+// RegisterCatalog registers the message templates of a locale e.g. de or de-CH.
+// The messages are added to the messages that are already registered for the locale and take precedence over the bundled catalogs.
+// Returns an error if a template cannot be parsed.
+func (v *Validator) RegisterCatalog(locale string, catalog Catalog) error {
+	compiled, err := compileCatalog(locale, catalog)
+	if err != nil {
+		return err
+	}
+
+	if v.registeredCatalogs == nil {
+		v.registeredCatalogs = map[string]*compiledCatalog{}
+	}
+
+	locale = normalizeLocale(locale)
+	existing, ok := v.registeredCatalogs[locale]
+	if !ok {
+		v.registeredCatalogs[locale] = compiled
+		return nil
+	}
+
+	for id, tmpl := range compiled.messages {
+		existing.messages[id] = tmpl
+	}
+
+	for unit, translation := range compiled.units {
+		existing.units[unit] = translation
+	}
+
+	return nil
+}
+
+// Translate translates the messages of a FieldError or ValidationErrors by the bundled catalog of the locale.
+// See Validator.Translate for details.
+func Translate(err error, locale string) error {
+	return NewValidator().Translate(err, locale)
+}
+
+// Translate translates the messages of a FieldError or ValidationErrors by the catalog of the locale.
+// The locale falls back to its language e.g. de for de-CH. Messages without template in the catalog and all other errors are returned unchanged.
+// The translated errors keep their fields and causes.
+func (v *Validator) Translate(err error, locale string) error {
+	switch typedErr := err.(type) {
+	case *FieldError:
+		return v.translateFieldError(typedErr, locale)
+	case ValidationErrors:
+		translated := make(ValidationErrors, len(typedErr))
+		for i, fieldErr := range typedErr {
+			translated[i] = v.translateFieldError(fieldErr, locale)
+		}
+		return translated
+	}
+
+	return err
+}
+
+// localize translates an error by the locale of the context if it has been set by WithLocale
+func (v *Validator) localize(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	locale, ok := LocaleFromContext(ctx)
+	if !ok {
+		return err
+	}
+
+	return v.Translate(err, locale)
+}
+
+func (v *Validator) translateFieldError(fieldErr *FieldError, locale string) *FieldError {
+	catalogs := v.catalogs(locale)
+	translated := *fieldErr
+
+	if fieldErr.MessageKey != "" {
+		// messages of tags are only translated if they are message keys of the catalog
+		tmpl := catalogs.message(fieldErr.MessageKey)
+		if tmpl == nil {
+			return fieldErr
+		}
+
+		return executeMessage(&translated, tmpl, catalogs)
+	}
+
+	tmpl := catalogs.message(fieldErr.Validator)
+	if tmpl == nil {
+		cause, ok := fieldErr.Err.(*FieldError)
+		if !ok {
+			return fieldErr
+		}
+
+		translatedCause := v.translateFieldError(cause, locale)
+		translated.Err = translatedCause
+		translated.message = translatedCause.message
+		return &translated
+	}
+
+	return executeMessage(&translated, tmpl, catalogs)
+}
+
+// executeMessage replaces the message of a copied field error by the executed template
+func executeMessage(fieldErr *FieldError, tmpl *template.Template, catalogs catalogChain) *FieldError {
+	data := MessageData{
+		Label:  fieldErr.Label,
+		Path:   fieldErr.Path,
+		Params: fieldErr.Params,
+		Value:  fieldErr.Value,
+		Kind:   fieldErr.Kind.String(),
+		Unit:   catalogs.unit(fieldErr.Kind),
+	}
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, data)
+	if err != nil {
+		// keep the original message if the template does not match the error e.g. due to missing parameters
+		return fieldErr
+	}
+
+	fieldErr.message = buf.String()
+	return fieldErr
+}
+
+// catalogChain contains the catalogs of a locale by their precedence
+type catalogChain []*compiledCatalog
+
+// catalogs returns the registered and bundled catalogs of a locale followed by the catalogs of its language
+func (v *Validator) catalogs(locale string) catalogChain {
+	locale = normalizeLocale(locale)
+	candidates := []string{locale}
+	if i := strings.Index(locale, "-"); i >= 0 {
+		candidates = append(candidates, locale[:i])
+	}
+
+	var chain catalogChain
+	for _, candidate := range candidates {
+		if catalog, ok := v.registeredCatalogs[candidate]; ok {
+			chain = append(chain, catalog)
+		}
+
+		if catalog, ok := bundledCatalogs()[candidate]; ok {
+			chain = append(chain, catalog)
+		}
+	}
+
+	return chain
+}
+
+func (chain catalogChain) message(id string) *template.Template {
+	for _, catalog := range chain {
+		if tmpl, ok := catalog.messages[id]; ok {
+			return tmpl
+		}
+	}
+
+	return nil
+}
+
+func (chain catalogChain) unit(kind reflect.Kind) string {
+	unit := cv.LengthUnit(kind)
+	if unit == "" {
+		return ""
+	}
+
+	for _, catalog := range chain {
+		if translation, ok := catalog.units[unit]; ok {
+			return translation
+		}
+	}
+
+	return unit
+}
+
+// normalizeLocale converts a locale to lower case and separates its parts by dashes e.g. de_CH to de-ch
+func normalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(locale), "_", "-")
+}
@@ -0,0 +1,123 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator/dv"
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+type TranslateUser struct {
+	Name    string            `validator:"len(9)" label:"Benutzername"`
+	Email   string            `validator:"email || len(0)"`
+	Age     int               `validator:"non-zero && min(18)"`
+	Role    string            `validator:"oneof('super admin',user)"`
+	Tags    []string          `validator:"max(1)"`
+	Address *TranslateAddress `label:"Adresse"`
+}
+
+type TranslateAddress struct {
+	City string `validator:"required" label:"Stadt"`
+}
+
+func TestValidator_Validate_translatesByLocaleOfContext(t *testing.T) {
+	validator := NewValidator()
+	valid := TranslateUser{Name: "012345678", Age: 18, Role: "user", Address: &TranslateAddress{City: "Berlin"}}
+
+	tests := []struct {
+		name     string
+		modify   func(user *TranslateUser)
+		locale   string
+		expected string
+	}{
+		{"len", func(user *TranslateUser) { user.Name = "short" }, "de", "Benutzername muss genau 9 Zeichen haben"},
+		{"len english", func(user *TranslateUser) { user.Name = "short" }, "en", "Benutzername must have exactly 9 characters"},
+		{"or", func(user *TranslateUser) { user.Email = "invalid" }, "de", "Email ist ungültig"},
+		{"and translates cause", func(user *TranslateUser) { user.Age = 17 }, "de-DE", "Age muss mindestens 18 sein"},
+		{"and translates first cause", func(user *TranslateUser) { user.Age = 0 }, "de", "Age darf nicht null sein"},
+		{"oneof", func(user *TranslateUser) { user.Role = "admin" }, "en_US", "Role must be one of super admin, user"},
+		{"max", func(user *TranslateUser) { user.Tags = []string{"a", "b"} }, "de", "Tags darf höchstens 1 Elemente haben"},
+		{"nested", func(user *TranslateUser) { user.Address.City = "" }, "de", "Stadt ist erforderlich"},
+		{"nil", func(user *TranslateUser) { user.Address = nil }, "de", "Stadt ist erforderlich"},
+		{"unknown locale", func(user *TranslateUser) { user.Name = "short" }, "fr", "len field Name has length 5, but should have length 9"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			user := valid
+			address := *valid.Address
+			user.Address = &address
+			test.modify(&user)
+
+			err := validator.Validate(WithLocale(context.Background(), test.locale), user)
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	validator := NewValidator()
+
+	err := validator.Validate(context.Background(), TranslateUser{Name: "short"})
+	assert.EqualError(t, err, "len field Name has length 5, but should have length 9")
+
+	var fieldErr *FieldError
+	if assert.True(t, errors.As(err, &fieldErr)) {
+		assert.Equal(t, "Name", fieldErr.Path)
+		assert.Equal(t, "Benutzername", fieldErr.Label)
+		assert.Equal(t, "len", fieldErr.Validator)
+		assert.Equal(t, "len(9)", fieldErr.SubTag)
+		assert.Equal(t, []string{"9"}, fieldErr.Params)
+		assert.Equal(t, "short", fieldErr.Value)
+	}
+
+	translated := Translate(err, "de")
+	assert.EqualError(t, translated, "Benutzername muss genau 9 Zeichen haben")
+	assert.True(t, errors.As(translated, &fieldErr))
+	assert.Equal(t, "len", fieldErr.Validator)
+	assert.IsType(t, dv.LenError(""), errors.Unwrap(translated))
+
+	notFieldErr := fmt.Errorf("no field error")
+	assert.Equal(t, notFieldErr, Translate(notFieldErr, "de"))
+	assert.Nil(t, Translate(nil, "de"))
+
+	errs := ValidationErrors{err.(*FieldError), err.(*FieldError)}
+	assert.EqualError(t, errs, "len field Name has length 5, but should have length 9; len field Name has length 5, but should have length 9")
+	assert.EqualError(t, Translate(errs, "en"), "Benutzername must have exactly 9 characters; Benutzername must have exactly 9 characters")
+}
+
+func TestValidator_RegisterCatalog(t *testing.T) {
+	validator := NewValidator()
+	validator.RegisterCustomValidator(cv.NewCustomValidator("even", regexp.MustCompile("^even$"), func(ctx context.Context, f *cv.Field, vCtx *cv.ValidationContext) error {
+		if f.Value.Int()%2 != 0 {
+			return fmt.Errorf("field %v is odd", vCtx.FieldName)
+		}
+		return nil
+	}, cv.NewCustomValidatorConfig()))
+
+	err := validator.RegisterCatalog("de-CH", Catalog{
+		Messages: map[string]string{"even": "{{.Label}} muss gerade sein, nicht {{.Value}}"},
+	})
+	assert.NoError(t, err)
+
+	type Number struct {
+		Value int    `validator:"even"`
+		Name  string `validator:"len(3)"`
+	}
+
+	err = validator.Validate(WithLocale(context.Background(), "de-CH"), Number{Value: 3, Name: "abc"})
+	assert.EqualError(t, err, "Value muss gerade sein, nicht 3")
+
+	// messages missing in the catalog of the locale fall back to the catalog of its language
+	err = validator.Validate(WithLocale(context.Background(), "de-CH"), Number{Value: 2})
+	assert.EqualError(t, err, "Name muss genau 3 Zeichen haben")
+
+	err = validator.RegisterCatalog("de", Catalog{Messages: map[string]string{"even": "{{if}}"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,34 @@
+package validator
+
+import (
+	"context"
+)
+
+// Result is the typed result of a validation by ValidateT
+type Result[T any] struct {
+	// Value is the validated value
+	Value T
+	// Err is the error of the validation or nil if the validation succeeded
+	Err error
+}
+
+// Valid reports whether the validation succeeded
+func (r Result[T]) Valid() bool {
+	return r.Err == nil
+}
+
+// Unwrap returns the validated value and the error of the validation
+func (r Result[T]) Unwrap() (T, error) {
+	return r.Value, r.Err
+}
+
+// ValidateT validates the provided value of a struct type or a pointer to a struct type and returns a typed result.
+// Usage:
+//
+//	user, err := validator.ValidateT(ctx, v, decodeUser()).Unwrap()
+func ValidateT[T any](ctx context.Context, v *Validator, value T) Result[T] {
+	return Result[T]{
+		Value: value,
+		Err:   v.Validate(ctx, value),
+	}
+}
@@ -0,0 +1,69 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gogo-gadget/validator/pkg/cv"
+)
+
+type LowercaseString string
+
+type TypedStruct struct {
+	Name     string          `validator:"lowercase"`
+	Pointer  *string         `validator:"lowercase"`
+	Custom   LowercaseString `validator:"lowercase"`
+	internal string          `validator:"lowercase"`
+}
+
+type InvalidKindTypedStruct struct {
+	Number int `validator:"lowercase"`
+}
+
+func lowercaseValidator() *cv.CustomValidator {
+	return cv.NewTyped("lowercase", regexp.MustCompile("lowercase"), func(ctx context.Context, s string, vCtx *cv.ValidationContext) error {
+		if strings.ToLower(s) != s {
+			return fmt.Errorf("lowercase field %v is not lowercase", vCtx.FieldName)
+		}
+		return nil
+	}, cv.NewCustomValidatorConfig())
+}
+
+func TestValidator_Validate_typedCustomValidator(t *testing.T) {
+	validator := NewValidator()
+	validator.RegisterCustomValidator(lowercaseValidator())
+	lower := "lower"
+
+	err := validator.Validate(context.Background(), TypedStruct{Name: "lower", Pointer: &lower, Custom: "lower", internal: "lower"})
+	assert.NoError(t, err)
+
+	err = validator.Validate(context.Background(), TypedStruct{Name: "lower", Pointer: &lower, Custom: "lower", internal: "Upper"})
+	assert.EqualError(t, err, "lowercase field internal is not lowercase")
+}
+
+func TestValidator_Validate_typedCustomValidatorFailsForInvalidValues(t *testing.T) {
+	validator := NewValidator()
+	validator.RegisterCustomValidator(lowercaseValidator())
+
+	err := validator.Validate(context.Background(), TypedStruct{})
+	assert.EqualError(t, err, "lowercase field Pointer is nil")
+
+	err = validator.Validate(context.Background(), InvalidKindTypedStruct{})
+	assert.EqualError(t, err, "lowercase field Number is of kind int and cannot be converted to string")
+}
+
+func TestValidateT(t *testing.T) {
+	validator := NewValidator()
+
+	result := ValidateT(context.Background(), validator, &And{Field: ValidEmail})
+	assert.True(t, result.Valid())
+
+	value, err := ValidateT(context.Background(), validator, And{Field: InvalidEmail}).Unwrap()
+	assert.Error(t, err)
+	assert.Equal(t, And{Field: InvalidEmail}, value)
+}
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/gogo-gadget/validator/pkg/cv"
 )
@@ -53,18 +54,46 @@ func (err *TagSyntaxError) WithFields(data map[string]interface{}) *TagSyntaxErr
 // Validator can be used to validate instances of structs or pointers to structs.
 // Uses StructFieldTags of form `validator:"..."` to identify validation rules on the field.
 // Contains a map of Custom Validators that will be used for the validation.
+// The struct field tag keys that are read can be configured by TagKeys.
 type Validator struct {
+	// CustomValidators are the custom validators by their IDs. They should be registered by RegisterCustomValidator,
+	// since the tags compiled by validators created by NewValidator are only recompiled on registration.
 	CustomValidators map[string]*cv.CustomValidator
+	// TagKeys are the struct field tag keys containing validation rules.
+	// If empty, the DefaultTagKey of the NativeSyntax is used.
+	TagKeys []TagKey
+	// Modifiers are the modifiers of the normalization by their IDs
+	Modifiers map[string]*cv.Modifier
+	// RemoteCache caches the results of the lookups of remote validators. If nil, the results are not cached.
+	RemoteCache RemoteCache
+	// Hooks observe the validations e.g. to export metrics. If nil, the validations are not observed.
+	Hooks Hooks
+	// Tracer starts spans for the validations, their fields and custom validators. If nil, no spans are started.
+	Tracer Tracer
+
+	macros             map[string]*macro
+	registeredCatalogs map[string]*compiledCatalog
+	remoteValidators   map[string]*RemoteValidator
+	// collected contains the field errors of a validation by ValidateAll instead of failing on the first error
+	collected *ValidationErrors
+	// tracing records the evaluation of the fields in the trace of the context of a validation by Trace
+	tracing bool
+	// collectingRemote evaluates only the remote validators to collect the keys of their lookups before a validation
+	collectingRemote bool
+	// plans caches the plans of the validated struct types
+	plans       *planCache
+	opaqueTypes map[reflect.Type]bool
 }
 
-// NewValidator creates a new instance of a validator and registers all provided default custom validators for it.
+// NewValidator creates a new instance of a validator and registers all provided default custom validators and modifiers for it.
 // Usage:
 // 		validator := NewValidator()
 //		err := validator.Validate(...)
 func NewValidator() *Validator {
-	v := &Validator{}
+	v := &Validator{plans: newPlanCache()}
 
 	v.RegisterDefaultCustomValidators()
+	v.RegisterDefaultModifiers()
 
 	return v
 }
@@ -76,17 +105,36 @@ func (v *Validator) RegisterCustomValidator(customValidator *cv.CustomValidator)
 	}
 
 	v.CustomValidators[customValidator.ID] = customValidator
+	v.plans.reset()
 }
 
 // Validate validates the provided interface{} and forwards the provided context to all custom validators.
 // Returns an error if the validation failed or nil otherwise.
 func (v *Validator) Validate(ctx context.Context, i interface{}) error {
+	ctx, end := v.observeValidation(ctx, reflect.TypeOf(i))
+	err := v.validate(ctx, i)
+	end(err)
+
+	return err
+}
+
+func (v *Validator) validate(ctx context.Context, i interface{}) (err error) {
+	defer recoverPanic(&err)
+
+	if i == nil {
+		return fmt.Errorf("validation of a nil value is not supported")
+	}
+
+	ctx = v.prefetchRemote(ctx, func(collector *Validator, ctx context.Context) {
+		_ = collector.validate(ctx, i)
+	})
+
 	iValue := reflect.ValueOf(i)
 	iType := iValue.Type()
 	kind := iValue.Kind()
 
 	// if the kind of the provided interface is interface or pointer use its underlying element instead
-	for kind == reflect.Interface || kind == reflect.Ptr || kind == reflect.UnsafePointer {
+	for kind == reflect.Interface || kind == reflect.Ptr {
 		if iValue.IsNil() {
 			// fail validators that should fail on a nil ptr
 			iType = getUnderlyingType(iType)
@@ -99,7 +147,7 @@ func (v *Validator) Validate(ctx context.Context, i interface{}) error {
 
 			err := v.validateStructNilValidations(iType, nil)
 			if err != nil {
-				return err
+				return v.localize(ctx, err)
 			}
 
 			return nil
@@ -114,18 +162,69 @@ func (v *Validator) Validate(ctx context.Context, i interface{}) error {
 		return fmt.Errorf("validation of kind %v is not supported", kind)
 	}
 
-	err := v.validateStruct(ctx, iValue, nil)
+	err = v.validateStruct(ctx, iValue, nil)
 	if err != nil {
+		return v.localize(ctx, err)
+	}
+
+	return nil
+}
+
+// ValidateAll validates the provided interface{} like Validate, but does not stop at the first invalid field.
+// Returns ValidationErrors containing the first error of every invalid field, any other error e.g. a TagSyntaxError
+// or nil if the validation succeeded.
+func (v *Validator) ValidateAll(ctx context.Context, i interface{}) error {
+	ctx, end := v.observeValidation(ctx, reflect.TypeOf(i))
+	err := v.validateAll(ctx, i)
+	end(err)
+
+	return err
+}
+
+func (v *Validator) validateAll(ctx context.Context, i interface{}) error {
+	collector := *v
+	collector.collected = &ValidationErrors{}
+
+	err := collector.validate(ctx, i)
+	if err != nil {
+		return err
+	}
+
+	if len(*collector.collected) > 0 {
+		return v.localize(ctx, *collector.collected)
+	}
+
+	return nil
+}
+
+// collect adds a field error to the collected errors if the validation collects all errors.
+// Returns the error if it has not been collected.
+func (v *Validator) collect(err error) error {
+	fieldErr, ok := err.(*FieldError)
+	if v.collected == nil || !ok {
 		return err
 	}
 
+	*v.collected = append(*v.collected, fieldErr)
 	return nil
 }
 
 // validateStruct should only be used on reflect.Values of kind struct
 func (v *Validator) validateStruct(ctx context.Context, structValue reflect.Value, parent *cv.Field) error {
+	return v.validateStructWithRules(ctx, structValue, parent, nil)
+}
+
+// validateStructWithRules validates a struct and the rules defined in code for its fields and the fields of its nested structs
+func (v *Validator) validateStructWithRules(ctx context.Context, structValue reflect.Value, parent *cv.Field, rules *structRules) error {
 	structType := structValue.Type()
+	plan := v.plan(structType)
 	for i := 0; i < structType.NumField(); i++ {
+		fieldPlan := plan.fields[i]
+		nested := rules.nestedRules(i)
+		if fieldPlan.skip && rules.field(i) == nil && nested == nil {
+			continue
+		}
+
 		structField := structType.Field(i)
 		fieldValue := structValue.Field(i)
 
@@ -133,9 +232,13 @@ func (v *Validator) validateStruct(ctx context.Context, structValue reflect.Valu
 			Parent:      parent,
 			StructField: structField,
 			Value:       fieldValue,
+			Struct:      structValue,
 		}
 
-		err := v.validateField(ctx, field)
+		err := v.validateField(ctx, field, rules.field(i), nested, fieldPlan.descend || nested != nil)
+		if err != nil {
+			err = v.collect(err)
+		}
 		if err != nil {
 			return err
 		}
@@ -144,27 +247,34 @@ func (v *Validator) validateStruct(ctx context.Context, structValue reflect.Valu
 	return nil
 }
 
-// validateField is run on every field and sub field of a struct
-func (v *Validator) validateField(ctx context.Context, field *cv.Field) error {
+// validateField is run on every field and sub field of a struct that is not skipped by the plan of the struct
+// Optionally rules that have been defined in code are validated in addition to the tags of the field and its nested struct.
+// Nested structs are only validated if the plan descends into the field.
+func (v *Validator) validateField(ctx context.Context, field *cv.Field, rules *Node, nested *structRules, descend bool) error {
+	defer repanic(field, "")
+
 	// Validate Field if it contains a subTag matching a regex of any custom validator
-	validatorTag := field.StructField.Tag.Get("validator")
-	err := v.runFieldValidation(ctx, field, validatorTag)
+	err := v.runFieldValidations(ctx, field, rules)
 	if err != nil {
 		return err
 	}
 
+	if !descend {
+		return nil
+	}
+
 	fValue := field.Value
 	fType := fValue.Type()
 	kind := fValue.Kind()
 
 	// if the kind of the Field is interface or pointer use its underlying element instead
-	for kind == reflect.Interface || kind == reflect.Ptr || kind == reflect.UnsafePointer {
+	for kind == reflect.Interface || kind == reflect.Ptr {
 		if fValue.IsNil() {
 			// fail validators that should fail on a nil ptr
 			fType = getUnderlyingType(fType)
 			kind = fType.Kind()
 
-			if kind != reflect.Struct {
+			if kind != reflect.Struct || v.isOpaque(fType) {
 				// if the kind is not struct there is nothing to be validated
 				return nil
 			}
@@ -182,13 +292,13 @@ func (v *Validator) validateField(ctx context.Context, field *cv.Field) error {
 		kind = fValue.Kind()
 	}
 
-	// If the Field is not of kind struct there is nothing to be validated anymore
-	if kind != reflect.Struct {
+	// If the Field is not of kind struct or of an opaque type there is nothing to be validated anymore
+	if kind != reflect.Struct || v.isOpaque(fType) {
 		return nil
 	}
 
 	// If the Field itself is of kind struct validate the nested struct
-	err = v.validateStruct(ctx, fValue, field)
+	err = v.validateStructWithRules(ctx, fValue, field, nested)
 	if err != nil {
 		return err
 	}
@@ -197,7 +307,13 @@ func (v *Validator) validateField(ctx context.Context, field *cv.Field) error {
 }
 
 func (v *Validator) validateStructNilValidations(structType reflect.Type, parent *cv.Field) error {
+	plan := v.plan(structType)
 	for i := 0; i < structType.NumField(); i++ {
+		fieldPlan := plan.fields[i]
+		if fieldPlan.skip {
+			continue
+		}
+
 		structField := structType.Field(i)
 
 		field := &cv.Field{
@@ -205,7 +321,10 @@ func (v *Validator) validateStructNilValidations(structType reflect.Type, parent
 			StructField: structField,
 		}
 
-		err := v.validateFieldNilValidations(field)
+		err := v.validateFieldNilValidations(structType, field, fieldPlan.descend)
+		if err != nil {
+			err = v.collect(err)
+		}
 		if err != nil {
 			return err
 		}
@@ -214,30 +333,44 @@ func (v *Validator) validateStructNilValidations(structType reflect.Type, parent
 	return nil
 }
 
-func (v *Validator) validateFieldNilValidations(field *cv.Field) error {
+func (v *Validator) validateFieldNilValidations(structType reflect.Type, field *cv.Field, descend bool) error {
+	defer repanic(field, "")
+
 	structField := field.StructField
-	validatorTag := structField.Tag.Get("validator")
-	subTags := strings.Split(validatorTag, ";")
+	node, err := v.compiledField(structType, structField)
+	if err != nil {
+		return withFieldPath(err, field)
+	}
 
-	for _, customValidator := range v.CustomValidators {
-		for _, subTag := range subTags {
-			if customValidator.TagRegex.MatchString(subTag) && customValidator.Config.ShouldFailIfFieldOfNilPtr {
+	for _, validation := range node.Validations() {
+		for _, b := range validation.bindings {
+			if b.customValidator.Config.ShouldFailIfFieldOfNilPtr {
 				fullFieldName := getFullFieldName(field)
-				return fmt.Errorf("validation failed since validator for regex: %v failed on nil value for Field: %v", customValidator.TagRegex.String(), fullFieldName)
+				return newFieldError(field, b.customValidator.ID, validation, b.validationCtx, nil,
+					fmt.Sprintf("validation failed since validator for regex: %v failed on nil value for Field: %v", b.customValidator.TagRegex.String(), fullFieldName))
 			}
 		}
 	}
 
+	if !descend {
+		return nil
+	}
+
 	fType := getUnderlyingType(structField.Type)
 	kind := fType.Kind()
 
-	// If the Field is not of kind struct there is nothing to be validated anymore
-	if kind != reflect.Struct {
+	// If the Field is not of kind struct or of an opaque type there is nothing to be validated anymore
+	if kind != reflect.Struct || v.isOpaque(fType) {
+		return nil
+	}
+
+	// The nil pointers of recursive types cannot contain any values
+	if fType == structType || isNestedIn(field.Parent, fType) {
 		return nil
 	}
 
 	// If the Field itself is of kind struct validate the nested struct
-	err := v.validateStructNilValidations(fType, field)
+	err = v.validateStructNilValidations(fType, field)
 	if err != nil {
 		return err
 	}
@@ -247,376 +380,73 @@ func (v *Validator) validateFieldNilValidations(field *cv.Field) error {
 
 // StructFieldTag validation
 
-// TODO validate validation tag syntax
-func (v *Validator) runFieldValidation(ctx context.Context, field *cv.Field, tag string) error {
-	strippedTag := removeWhiteSpace(tag)
-	return v.executeFieldValidation(ctx, field, strippedTag)
-}
-
-// TODO add multiple errors to return
-func (v *Validator) executeFieldValidation(ctx context.Context, field *cv.Field, tag string) error {
-	if strings.HasPrefix(tag, "if(") {
-		// tag starts with if( statement
-		return v.executeIfFieldValidation(ctx, field, tag)
-	} else if strings.HasPrefix(tag, "!") {
-		// tag starts with ! statement
-		return v.executeNotFieldValidation(ctx, field, tag)
-	} else if strings.HasPrefix(tag, "(") {
-		// tag starts with statement in brackets
-		return v.executeBracketFieldValidation(ctx, field, tag)
-	}
-
-	// assume tag starts with validation tag
-	return v.executeTagFieldValidation(ctx, field, tag)
-}
-
-func (v *Validator) executeIfFieldValidation(ctx context.Context, field *cv.Field, tag string) error {
-	tagLen := len(tag)
-
-	numOpenBraces := 1
-	i := 3
-	// look forward until all open braces of if condition are closed
-	for numOpenBraces > 0 {
-		if tag[i] == '(' {
-			numOpenBraces++
-		} else if tag[i] == ')' {
-			numOpenBraces--
-		}
-		i++
-	}
-
-	if i+7 > tagLen || tag[i:i+5] != "then(" {
-		// syntax error
-		return SyntaxErrorf("if condition must be followed by then statement").
-			WithFields(map[string]interface{}{
-				"tag":        tag,
-				"field-path": getFullFieldName(field),
-			})
-	}
-
-	numOpenBraces = 1
-	j := i + 5
-	// look forward until all open braces of then statement are closed
-	for numOpenBraces > 0 {
-		if tag[j] == '(' {
-			numOpenBraces++
-		} else if tag[j] == ')' {
-			numOpenBraces--
-		}
-		j++
+// The syntax of tags is validated when they are evaluated or upfront for a whole type by Check
+func (v *Validator) runFieldValidations(ctx context.Context, field *cv.Field, rules *Node) error {
+	node, err := v.compiledField(field.Struct.Type(), field.StructField)
+	if err != nil {
+		return withFieldPath(err, field)
 	}
 
-	// read to end => only one sub validation
-	if j == tagLen {
-		conditionErr := v.executeFieldValidation(ctx, field, tag[3:i-1])
-
-		if conditionErr == nil {
-			return v.executeFieldValidation(ctx, field, tag[i+5:j-1])
-		}
+	if node == nil && rules == nil {
 		return nil
 	}
 
-	if j+2 >= tagLen {
-		// at least 3 characters must follow then statement at this point
-		return SyntaxErrorf("then statement must be followed by &&, ||, elif or else statement").
-			WithFields(map[string]interface{}{
-				"tag":        tag,
-				"field-path": getFullFieldName(field),
-			})
-	}
-
-	k := j
-	if j+7 < tagLen {
-		// read all elif and possible else statements
-		if tag[k:k+5] == "elif(" {
-			// read all elif statements
-			k = j + 5
-			numOpenBraces = 1
-			for numOpenBraces > 0 {
-				if tag[k] == '(' {
-					numOpenBraces++
-				} else if tag[k] == ')' {
-					numOpenBraces--
-				}
-				k++
-			}
-
-			if k+7 >= tagLen || tag[i:i+5] != "then(" {
-				// syntax error
-				return SyntaxErrorf("elif condition must be followed by then statement").
-					WithFields(map[string]interface{}{
-						"tag":        tag,
-						"field-path": getFullFieldName(field),
-					})
-			}
+	ctx, end := v.observeField(ctx, field)
+	ctx = v.traceField(ctx, field)
+	err = v.runFieldValidation(ctx, field, node, rules)
+	end(err)
 
-			numOpenBraces = 1
-			k = k + 5
-			// look forward until all open braces of then statement are closed
-			for numOpenBraces > 0 {
-				if tag[k] == '(' {
-					numOpenBraces++
-				} else if tag[k] == ')' {
-					numOpenBraces--
-				}
-				k++
-			}
-		}
-
-		if k+7 < tagLen {
-			if tag[k:k+5] == "else(" {
-				// read else statement
-				k = k + 5
-				numOpenBraces = 1
-				for numOpenBraces > 0 {
-					if tag[k] == '(' {
-						numOpenBraces++
-					} else if tag[k] == ')' {
-						numOpenBraces--
-					}
-					k++
-				}
-			}
-		}
-	}
-
-	if k == tagLen {
-		// read to the end of the tag => no && or || statement followed entire if statement
-		conditionErr := v.executeFieldValidation(ctx, field, tag[3:i-1])
-
-		if conditionErr == nil {
-			// return execution of first then statement
-			return v.executeFieldValidation(ctx, field, tag[i+5:j-1])
-		}
-
-		if j+7 >= tagLen {
-			// at least 7 characters must follow previous if then statement at this point
-			return SyntaxErrorf("then statement must be followed by &&, ||, elif or else statement").
-				WithFields(map[string]interface{}{
-					"tag":        tag,
-					"field-path": getFullFieldName(field),
-				})
-		}
-
-		if tag[j:j+5] == "else(" {
-			// return execution of else statement
-			return v.executeFieldValidation(ctx, field, tag[j+5:tagLen-1])
-		} else if tag[j:j+5] == "elif(" {
-			// return execution starting from elif statement
-			return v.executeFieldValidation(ctx, field, tag[j+2:])
-		}
-
-		return SyntaxErrorf("then statement must be followed by elif or else statement").
-			WithFields(map[string]interface{}{
-				"tag":        tag,
-				"field-path": getFullFieldName(field),
-			})
-	}
-
-	if k+2 >= tagLen {
-		// at least 3 characters must follow then statement at this point
-		return SyntaxErrorf("statement must be followed by && or || statement").
-			WithFields(map[string]interface{}{
-				"tag":        tag,
-				"field-path": getFullFieldName(field),
-			})
-	}
-
-	// validate logical operators "&&" and "||"
-	if tag[k:k+2] == "&&" {
-		return v.executeAndFieldValidation(ctx, field, tag[:k], tag[k+2:])
-	} else if tag[k:k+2] == "||" {
-		return v.executeOrFieldValidation(ctx, field, tag[:k], tag[k+2:])
-	}
-
-	return SyntaxErrorf("then statement must be followed by && or ||").
-		WithFields(map[string]interface{}{
-			"tag":        tag,
-			"field-path": getFullFieldName(field),
-		})
+	return err
 }
 
-func (v *Validator) executeNotFieldValidation(ctx context.Context, field *cv.Field, tag string) error {
-	tagLen := len(tag)
-
-	if tagLen < 3 {
-		// only one sub validation
-		if v.executeFieldValidation(ctx, field, tag[1:]) != nil {
-			return nil
-		}
-
-		return fmt.Errorf("validation of %v of Field %v failed", tag, getFullFieldName(field))
-	}
-
-	numOpenBraces := 0
-	i := 1
-	// look forward until all open braces are closed and either && or || follows
-	// last two characters may not be && or ||
-	for (i+2 < tagLen && tag[i:i+2] != "&&" && tag[i:i+2] != "||") || numOpenBraces > 0 {
-		if tag[i] == '(' {
-			numOpenBraces++
-		} else if tag[i] == ')' {
-			numOpenBraces--
-		}
-		i++
-	}
-
-	// read to end => only one sub validation
-	if i+2 >= tagLen {
-		if v.executeFieldValidation(ctx, field, tag[1:]) != nil {
-			return nil
+// runFieldValidation evaluates the tags of a field and the rules defined in code for it
+func (v *Validator) runFieldValidation(ctx context.Context, field *cv.Field, node *Node, rules *Node) error {
+	if node != nil {
+		err := v.evaluate(ctx, field, node)
+		if err != nil {
+			return err
 		}
-
-		return fmt.Errorf("validation of %v of Field %v failed", tag, getFullFieldName(field))
 	}
 
-	// validate logical operators && and ||
-	if tag[i:i+2] == "&&" {
-		return v.executeAndFieldValidation(ctx, field, tag[:i], tag[i+2:])
-	} else if tag[i:i+2] == "||" {
-		return v.executeOrFieldValidation(ctx, field, tag[:i], tag[i+2:])
+	if rules != nil {
+		return v.runFieldRules(ctx, field, rules)
 	}
 
-	// should not happen
-	return SyntaxErrorf("negation operator must be followed by && or || logical operator").WithFields(map[string]interface{}{
-		"tag":        tag,
-		"field-path": field,
-	})
+	return nil
 }
 
-func (v *Validator) executeBracketFieldValidation(ctx context.Context, field *cv.Field, tag string) error {
-	numOpenBraces := 1
-	i := 1
-
-	// look forward until all open braces are closed
-	for numOpenBraces > 0 {
-		if tag[i] == '(' {
-			numOpenBraces++
-		} else if tag[i] == ')' {
-			numOpenBraces--
-		}
-		i++
-	}
-
-	// read to end => only one sub validation
-	if i == len(tag) {
-		return v.executeFieldValidation(ctx, field, tag[1:i-1])
-	}
-
-	// validate logical operators && and ||
-	if tag[i:i+2] == "&&" {
-		return v.executeAndFieldValidation(ctx, field, tag[1:i-1], tag[i+2:])
-	} else if tag[i:i+2] == "||" {
-		return v.executeOrFieldValidation(ctx, field, tag[1:i-1], tag[i+2:])
-	}
-
-	// should not happen
-	return SyntaxErrorf("closing bracket must be followed by && or || logical operator").
-		WithFields(map[string]interface{}{
-			"tag":        tag,
-			"field-path": getFullFieldName(field),
-		})
-}
+// Utility Methods
 
-func (v *Validator) executeTagFieldValidation(ctx context.Context, field *cv.Field, tag string) error {
-	tagLen := len(tag)
-
-	if tagLen < 3 {
-		// only one sub validation
-		// the tag has to be a validation tag
-		for _, customValidator := range v.CustomValidators {
-			if customValidator.TagRegex.MatchString(tag) {
-				validationCtx := &cv.ValidationContext{
-					SubTag: tag,
-				}
-				err := customValidator.Validate(ctx, field, validationCtx)
-				if err != nil {
-					return err
-				}
+// removes the whitespace from a provided string except for whitespace within string literals
+func removeWhiteSpace(str string) string {
+	var sb strings.Builder
+	for i := 0; i < len(str); i++ {
+		if cv.IsQuote(str[i]) {
+			end, err := cv.ScanLiteral(str, i)
+			if err == nil {
+				sb.WriteString(str[i:end])
+				i = end - 1
+				continue
 			}
 		}
 
-		return fmt.Errorf("validation of %v of Field %v failed", tag, getFullFieldName(field))
-	}
-
-	numOpenBraces := 0
-	i := 1
-	// look forward until all open braces are closed and either && or || follows
-	// !if()then() is not supported
-	for (i+2 < tagLen && tag[i:i+2] != "&&" && tag[i:i+2] != "||") || numOpenBraces > 0 {
-		if tag[i] == '(' {
-			numOpenBraces++
-		} else if tag[i] == ')' {
-			numOpenBraces--
-		}
-		i++
-	}
-
-	// read to end => only one sub validation
-	if i+2 >= tagLen {
-		// the tag has to be a single validation tag
-		for _, customValidator := range v.CustomValidators {
-			if customValidator.TagRegex.MatchString(tag) {
-				validationCtx := &cv.ValidationContext{
-					SubTag: tag,
-				}
-				err := customValidator.Validate(ctx, field, validationCtx)
-				if err != nil {
-					return err
-				}
-			}
+		r, size := utf8.DecodeRuneInString(str[i:])
+		if !unicode.IsSpace(r) {
+			sb.WriteRune(r)
 		}
-
-		return nil
-	}
-
-	// validate logical operators && and ||
-	if tag[i:i+2] == "&&" {
-		return v.executeAndFieldValidation(ctx, field, tag[:i], tag[i+2:])
-	} else if tag[i:i+2] == "||" {
-		return v.executeOrFieldValidation(ctx, field, tag[:i], tag[i+2:])
-	}
-
-	// should not happen
-	return SyntaxErrorf("validation tag must be followed by && or || logical operator").
-		WithFields(map[string]interface{}{
-			"tag":        tag,
-			"field-path": getFullFieldName(field),
-		})
-}
-
-func (v *Validator) executeAndFieldValidation(ctx context.Context, field *cv.Field, tag1, tag2 string) error {
-	error1 := v.executeFieldValidation(ctx, field, tag1)
-	error2 := v.executeFieldValidation(ctx, field, tag2)
-
-	if error1 != nil || error2 != nil {
-		return fmt.Errorf("&& validation of %v and %v of Field %v failed", tag1, tag2, getFullFieldName(field))
+		i += size - 1
 	}
-	return nil
-}
 
-func (v *Validator) executeOrFieldValidation(ctx context.Context, field *cv.Field, tag1, tag2 string) error {
-	error1 := v.executeFieldValidation(ctx, field, tag1)
-	error2 := v.executeFieldValidation(ctx, field, tag2)
-
-	if error1 != nil && error2 != nil {
-		return fmt.Errorf("|| validation of %v or %v of Field %v failed", tag1, tag2, getFullFieldName(field))
-	}
-	return nil
+	return sb.String()
 }
 
-// Utility Methods
-
-// removes the whitespace from a provided string
-func removeWhiteSpace(str string) string {
-	strippedStr := ""
-	for _, r := range str {
-		if !unicode.IsSpace(r) {
-			strippedStr = fmt.Sprintf("%v%c", strippedStr, r)
-		}
+// withFieldPath adds the full field name to tag syntax errors
+func withFieldPath(err error, field *cv.Field) error {
+	if syntaxErr, ok := err.(*TagSyntaxError); ok {
+		return syntaxErr.WithField("field-path", getFullFieldName(field))
 	}
 
-	return strippedStr
+	return err
 }
 
 func getFullFieldName(field *cv.Field) string {
@@ -634,12 +464,21 @@ func getFullFieldName(field *cv.Field) string {
 	return fullFieldName
 }
 
-func getUnderlyingType(rType reflect.Type) reflect.Type {
-	kind := rType.Kind()
+// isNestedIn returns whether a struct type is the type of the field or any of its parents
+func isNestedIn(field *cv.Field, structType reflect.Type) bool {
+	for ; field != nil; field = field.Parent {
+		if getUnderlyingType(field.StructField.Type) == structType {
+			return true
+		}
+	}
+
+	return false
+}
 
-	for kind == reflect.Interface || kind == reflect.Ptr || kind == reflect.UnsafePointer {
+// getUnderlyingType returns the type a pointer type points to. Interfaces have no underlying type without a value.
+func getUnderlyingType(rType reflect.Type) reflect.Type {
+	for rType.Kind() == reflect.Ptr {
 		rType = rType.Elem()
-		kind = rType.Kind()
 	}
 
 	return rType
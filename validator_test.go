@@ -2,6 +2,7 @@ package validator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -183,3 +184,88 @@ func TestValidator_Validate_ifStatement(t *testing.T) {
 		})
 	}
 }
+
+type DiveStruct struct {
+	Tags []string `validator:"dive(required && min(2))"`
+}
+
+func TestValidator_Validate_diveFieldPath(t *testing.T) {
+	validator := NewValidator()
+
+	err := validator.Validate(context.Background(), DiveStruct{Tags: []string{"ab", "c"}})
+
+	assert.EqualError(t, err, "&& validation of required and min(2) of Field Tags[1] failed")
+}
+
+type UntaggedFieldStruct struct {
+	Tagged   string `validator:"email"`
+	Untagged string
+}
+
+func TestValidator_Validate_ignoresUntaggedFields(t *testing.T) {
+	validator := NewValidator()
+
+	err := validator.Validate(context.Background(), UntaggedFieldStruct{Tagged: ValidEmail})
+
+	assert.NoError(t, err)
+}
+
+type LiteralStruct struct {
+	City    string `validator:"oneof('New York', 'Los Angeles')"`
+	Pattern string `validator:"regex('^(a||b);$') || len(3)"`
+}
+
+func TestValidator_Validate_stringLiterals(t *testing.T) {
+	validator := NewValidator()
+
+	values := []interface{}{
+		LiteralStruct{City: "New York", Pattern: "a;"},
+		LiteralStruct{City: "Los Angeles", Pattern: "abc"},
+	}
+
+	for _, val := range values {
+		err := validator.Validate(context.Background(), val)
+		assert.NoError(t, err)
+	}
+
+	err := validator.Validate(context.Background(), LiteralStruct{City: "NewYork", Pattern: "a;"})
+	assert.Error(t, err)
+}
+
+type ValidateAllStruct struct {
+	Name    string `validator:"len(3)"`
+	Email   string `validator:"email"`
+	Address *ValidateAllAddress
+	Missing *ValidateAllAddress
+}
+
+type ValidateAllAddress struct {
+	City string `validator:"required"`
+}
+
+func TestValidator_ValidateAll(t *testing.T) {
+	validator := NewValidator()
+
+	err := validator.ValidateAll(context.Background(), ValidateAllStruct{Name: "abc", Email: ValidEmail, Address: &ValidateAllAddress{City: "Berlin"}, Missing: &ValidateAllAddress{City: "Rome"}})
+	assert.NoError(t, err)
+
+	err = validator.ValidateAll(context.Background(), &ValidateAllStruct{Name: "ab", Email: "invalid", Address: &ValidateAllAddress{}})
+
+	var errs ValidationErrors
+	if assert.True(t, errors.As(err, &errs)) && assert.Len(t, errs, 4) {
+		assert.Equal(t, "Name", errs[0].Path)
+		assert.Equal(t, "Email", errs[1].Path)
+		assert.Equal(t, "Address.City", errs[2].Path)
+		assert.Equal(t, "Missing.City", errs[3].Path)
+	}
+
+	// the validation still fails on the first error
+	err = validator.Validate(context.Background(), &ValidateAllStruct{Name: "ab", Email: "invalid"})
+	assert.IsType(t, &FieldError{}, err)
+
+	err = validator.ValidateAll(WithLocale(context.Background(), "de"), ValidateAllStruct{Name: "ab", Email: ValidEmail, Address: &ValidateAllAddress{City: "Berlin"}, Missing: &ValidateAllAddress{City: "Rome"}})
+	assert.EqualError(t, err, "Name muss genau 3 Zeichen haben")
+
+	err = validator.ValidateAll(context.Background(), 1)
+	assert.EqualError(t, err, "validation of kind int is not supported")
+}